@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"shell-history-client/internal/buffer"
+
+	"github.com/spf13/cobra"
+)
+
+var bufferCmd = &cobra.Command{
+	Use:   "buffer",
+	Short: "Manage the local command buffer",
+}
+
+var bufferRekeyCmd = &cobra.Command{
+	Use:   "rekey",
+	Short: "Generate a new buffer encryption key and re-seal the buffer",
+	Long: `Generate a new encryption key for the local command buffer and
+re-save its contents under it, replacing the key stored in
+~/.config/shell-history/buffer.key.
+
+Requires encrypt_buffer: true in config.json (see "shell-history
+config show").`,
+	Run: func(cmd *cobra.Command, args []string) {
+		bm, err := buffer.NewBufferManagerWithEncryption(cfg.EncryptBuffer)
+		if err != nil {
+			cmd.Printf("Error accessing buffer: %v\n", err)
+			return
+		}
+
+		if err := bm.Rekey(); err != nil {
+			cmd.Printf("Error rekeying buffer: %v\n", err)
+			return
+		}
+
+		cmd.Println("Buffer re-encrypted under a new key")
+	},
+}
+
+func init() {
+	bufferCmd.AddCommand(bufferRekeyCmd)
+	rootCmd.AddCommand(bufferCmd)
+}
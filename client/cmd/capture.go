@@ -3,24 +3,35 @@ package cmd
 import (
 	"os"
 	"shell-history-client/internal/buffer"
+	"shell-history-client/internal/capture"
 	"shell-history-client/internal/client"
 	"shell-history-client/internal/models"
 	"shell-history-client/internal/redaction"
 	"shell-history-client/internal/session"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 )
 
 var (
-	exitCode int
-	cwd      string
+	exitCode   int
+	cwd        string
+	startedAt  string
+	durationMS int64
+	shellName  string
+	tagFlags   []string
+	syncSend   bool
 )
 
 var captureCmd = &cobra.Command{
 	Use:   "capture [command]",
 	Short: "Capture a shell command",
-	Args:  cobra.ExactArgs(1),
+	Long: `Record a command into the local buffer for "shell-history daemon"
+(or "shell-history flush") to deliver, so the interactive shell hook
+never blocks on a network round-trip. Pass --sync to send it to the
+server immediately instead, falling back to the buffer on failure.`,
+	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		commandText := args[0]
 
@@ -29,14 +40,14 @@ var captureCmd = &cobra.Command{
 			return // Silent fail for capture
 		}
 
-		bm, err := buffer.NewBufferManager()
+		bm, err := buffer.NewBufferManagerWithEncryption(cfg.EncryptBuffer)
 		if err != nil {
 			return
 		}
 
-		// Apply client-side redaction if rules are configured
-		redactor := redaction.NewRedactor(cfg.RedactionRules)
-		redactedCommand, wasRedacted := redactor.Redact(commandText)
+		// Apply client-side redaction: built-in presets first, then user rules
+		redactor := redaction.NewRedactorWithPresets(cfg.RedactionPresets, cfg.RedactionRules)
+		redactedCommand, redactedBy := redactor.RedactVerbose(commandText)
 
 		hostname, _ := os.Hostname()
 		user := os.Getenv("USER")
@@ -53,24 +64,66 @@ var captureCmd = &cobra.Command{
 			Hostname:    hostname,
 			Username:    user,
 			AltUsername: os.Getenv("SUDO_USER"),
-			CWD:         cwd,
 			ExitCode:    &exitCode,
 			SessionID:   sm.SessionID,
-			Redacted:    wasRedacted,
+			Redacted:    len(redactedBy) > 0,
+			RedactedBy:  redactedBy,
+			FinishedAt:  time.Now().UTC().Format(time.RFC3339),
 		}
 
-		apiClient := client.NewAPIClient(cfg.ServerURL, cfg.APIKey)
-		err = apiClient.Capture(cmdModel)
-		if err != nil {
-			// Fallback to buffer
-			cmdModel.Timestamp = time.Now().UTC().Format("2006-01-02T15:04:05.000000Z")
-			bm.Add(cmdModel)
+		ctx := &capture.Context{
+			CWD:        cwd,
+			StartedAt:  startedAt,
+			DurationMS: durationMS,
+			Shell:      shellName,
+			Tags:       mergeTags(cfg.Tags, tagFlags),
+		}
+		capture.New(cfg.ContextEnrichers).Run(ctx, &cmdModel, redactor)
+
+		if syncSend {
+			apiClient := client.NewAPIClientFromConfig(cfg)
+			if err := apiClient.Capture(cmdModel); err == nil {
+				return
+			}
 		}
+
+		// Default path: enqueue into the local buffer and return
+		// immediately. "shell-history daemon" (or a manual "flush")
+		// owns actually delivering it, so the shell prompt hook never
+		// blocks on a network round-trip.
+		cmdModel.Timestamp = time.Now().UTC().Format("2006-01-02T15:04:05.000000Z")
+		bm.Add(cmdModel)
 	},
 }
 
+// mergeTags combines static config tags with --tag flags of the form
+// "key=value", with flags taking precedence over config on key collision.
+func mergeTags(configTags map[string]string, flags []string) map[string]string {
+	if len(configTags) == 0 && len(flags) == 0 {
+		return nil
+	}
+
+	tags := make(map[string]string, len(configTags)+len(flags))
+	for k, v := range configTags {
+		tags[k] = v
+	}
+	for _, flag := range flags {
+		key, value, ok := strings.Cut(flag, "=")
+		if !ok {
+			continue
+		}
+		tags[key] = value
+	}
+	return tags
+}
+
 func init() {
 	captureCmd.Flags().IntVar(&exitCode, "exit-code", 0, "Command exit code")
 	captureCmd.Flags().StringVar(&cwd, "cwd", "", "Current working directory")
+	captureCmd.Flags().StringVar(&startedAt, "started-at", "", "When the command started, RFC3339 (e.g. zsh's $EPOCHREALTIME)")
+	captureCmd.Flags().Int64Var(&durationMS, "duration-ms", 0, "How long the command ran, in milliseconds")
+	captureCmd.Flags().StringVar(&shellName, "shell", "", "Shell the command ran in (e.g. zsh, bash)")
+	captureCmd.Flags().StringArrayVar(&tagFlags, "tag", nil, "Tag to attach as key=value (repeatable)")
+	captureCmd.Flags().BoolVar(&syncSend, "sync", false, "Send to the server immediately instead of buffering for the daemon/flush to deliver")
 	rootCmd.AddCommand(captureCmd)
 }
@@ -1,6 +1,11 @@
 package cmd
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"shell-history-client/internal/capture"
 	"shell-history-client/internal/config"
 
 	"github.com/spf13/cobra"
@@ -24,6 +29,33 @@ var configShowCmd = &cobra.Command{
 		} else {
 			cmd.Println("API Key: (not set)")
 		}
+		if cfg.Socket != "" {
+			cmd.Printf("Socket: %s\n", cfg.Socket)
+		}
+		cmd.Printf("Encrypt buffer: %v\n", cfg.EncryptBuffer)
+		if cfg.IssuerURL != "" {
+			cmd.Printf("Issuer URL: %s\n", cfg.IssuerURL)
+		}
+		if cfg.ClientID != "" {
+			cmd.Printf("Client ID: %s\n", cfg.ClientID)
+		}
+		if cfg.AccessToken != "" {
+			cmd.Println("Logged in: yes (OIDC)")
+		}
+		switch {
+		case len(cfg.ContextEnrichers) == 0:
+			cmd.Println("Context enrichers: all")
+		case len(cfg.ContextEnrichers) == 1 && cfg.ContextEnrichers[0] == capture.None:
+			cmd.Println("Context enrichers: none")
+		default:
+			cmd.Printf("Context enrichers: %s\n", strings.Join(cfg.ContextEnrichers, ", "))
+		}
+		if len(cfg.Tags) > 0 {
+			cmd.Print("Tags:\n")
+			for k, v := range cfg.Tags {
+				cmd.Printf("  %s=%s\n", k, v)
+			}
+		}
 	},
 }
 
@@ -63,9 +95,169 @@ var configSetServerCmd = &cobra.Command{
 	},
 }
 
+var configSetSocketCmd = &cobra.Command{
+	Use:   "set-socket [path]",
+	Short: "Set the Unix domain socket to dial instead of the server URL",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		socketPath := args[0]
+		cfg := config.LoadConfig()
+		cfg.Socket = socketPath
+
+		if err := config.SaveConfig(cfg); err != nil {
+			cmd.Printf("Error saving configuration: %v\n", err)
+			return
+		}
+
+		cmd.Printf("Socket set to: %s\n", socketPath)
+	},
+}
+
+var configSetEncryptBufferCmd = &cobra.Command{
+	Use:   "set-encrypt-buffer <true|false>",
+	Short: "Enable or disable at-rest encryption of the local command buffer",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		enabled, err := strconv.ParseBool(args[0])
+		if err != nil {
+			fmt.Printf("Error: %q is not a valid boolean\n", args[0])
+			return
+		}
+
+		cfg := config.LoadConfig()
+		cfg.EncryptBuffer = enabled
+
+		if err := config.SaveConfig(cfg); err != nil {
+			cmd.Printf("Error saving configuration: %v\n", err)
+			return
+		}
+
+		cmd.Printf("Encrypt buffer set to: %v\n", enabled)
+	},
+}
+
+var configSetIssuerCmd = &cobra.Command{
+	Use:   "set-issuer [url]",
+	Short: "Set the OIDC issuer URL used by 'shell-history login'",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		issuerURL := args[0]
+		cfg := config.LoadConfig()
+		cfg.IssuerURL = issuerURL
+
+		if err := config.SaveConfig(cfg); err != nil {
+			cmd.Printf("Error saving configuration: %v\n", err)
+			return
+		}
+
+		cmd.Printf("Issuer URL set to: %s\n", issuerURL)
+	},
+}
+
+var configSetClientIDCmd = &cobra.Command{
+	Use:   "set-client-id [id]",
+	Short: "Set the OAuth2 client ID used by 'shell-history login'",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		clientID := args[0]
+		cfg := config.LoadConfig()
+		cfg.ClientID = clientID
+
+		if err := config.SaveConfig(cfg); err != nil {
+			cmd.Printf("Error saving configuration: %v\n", err)
+			return
+		}
+
+		cmd.Printf("Client ID set to: %s\n", clientID)
+	},
+}
+
+var configSetContextEnrichersCmd = &cobra.Command{
+	Use:   "set-context-enrichers <name>[,<name>...]|all|none",
+	Short: "Choose which internal/capture enrichers run on captured commands",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		var enabled []string
+		switch args[0] {
+		case "all":
+			enabled = nil
+		case capture.None:
+			enabled = []string{capture.None}
+		default:
+			known := make(map[string]bool)
+			for _, name := range capture.Names() {
+				known[name] = true
+			}
+			for _, name := range strings.Split(args[0], ",") {
+				if !known[name] {
+					cmd.Printf("Error: unknown enricher %q (known enrichers: %s)\n", name, strings.Join(capture.Names(), ", "))
+					return
+				}
+				enabled = append(enabled, name)
+			}
+		}
+
+		cfg.ContextEnrichers = enabled
+		if err := saveConfig(); err != nil {
+			cmd.Printf("Error saving configuration: %v\n", err)
+			return
+		}
+
+		cmd.Printf("Context enrichers set to: %s\n", args[0])
+	},
+}
+
+var configSetTagCmd = &cobra.Command{
+	Use:   "set-tag <key>=<value>",
+	Short: "Set a static tag attached to every captured command",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		key, value, ok := strings.Cut(args[0], "=")
+		if !ok {
+			cmd.Printf("Error: %q is not in key=value form\n", args[0])
+			return
+		}
+
+		if cfg.Tags == nil {
+			cfg.Tags = make(map[string]string)
+		}
+		cfg.Tags[key] = value
+
+		if err := saveConfig(); err != nil {
+			cmd.Printf("Error saving configuration: %v\n", err)
+			return
+		}
+
+		cmd.Printf("Tag %s set to: %s\n", key, value)
+	},
+}
+
+var configUnsetTagCmd = &cobra.Command{
+	Use:   "unset-tag <key>",
+	Short: "Remove a static tag",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		delete(cfg.Tags, args[0])
+
+		if err := saveConfig(); err != nil {
+			cmd.Printf("Error saving configuration: %v\n", err)
+			return
+		}
+
+		cmd.Printf("Tag %s removed\n", args[0])
+	},
+}
+
 func init() {
 	configCmd.AddCommand(configShowCmd)
 	configCmd.AddCommand(configSetAPIKeyCmd)
 	configCmd.AddCommand(configSetServerCmd)
+	configCmd.AddCommand(configSetSocketCmd)
+	configCmd.AddCommand(configSetEncryptBufferCmd)
+	configCmd.AddCommand(configSetIssuerCmd)
+	configCmd.AddCommand(configSetClientIDCmd)
+	configCmd.AddCommand(configSetContextEnrichersCmd)
+	configCmd.AddCommand(configSetTagCmd)
+	configCmd.AddCommand(configUnsetTagCmd)
 	rootCmd.AddCommand(configCmd)
 }
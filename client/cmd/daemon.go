@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"shell-history-client/internal/buffer"
+	"shell-history-client/internal/client"
+	"shell-history-client/internal/flusher"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	daemonInterval  time.Duration
+	daemonBatchSize int
+)
+
+var daemonCmd = &cobra.Command{
+	Use:   "daemon",
+	Short: "Run the background buffer flusher",
+	Long: `Run a long-lived process that periodically drains the local
+command buffer to the server in batches, backing off exponentially
+while the server is unreachable.
+
+This is what actually delivers what "shell-history capture" writes to
+the buffer by default: run "shell-history daemon" under a supervisor
+(systemd, launchd, etc.) so the interactive shell hook never blocks on
+a network round-trip.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		bm, err := buffer.NewBufferManagerWithEncryption(cfg.EncryptBuffer)
+		if err != nil {
+			cmd.Printf("Error accessing buffer: %v\n", err)
+			return
+		}
+
+		apiClient := client.NewAPIClientFromConfig(cfg)
+		f := flusher.New(bm, apiClient, daemonInterval, daemonBatchSize)
+
+		stop := make(chan struct{})
+		sig := make(chan os.Signal, 1)
+		signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+		go func() {
+			<-sig
+			close(stop)
+		}()
+
+		cmd.Printf("Flushing buffer at %s every %s (batch size %d)\n", cfg.ServerURL, daemonInterval, daemonBatchSize)
+		f.Run(stop)
+	},
+}
+
+func init() {
+	daemonCmd.Flags().DurationVar(&daemonInterval, "interval", flusher.DefaultInterval, "How often to attempt a flush")
+	daemonCmd.Flags().IntVar(&daemonBatchSize, "batch-size", flusher.DefaultBatchSize, "Max commands per batch request")
+	rootCmd.AddCommand(daemonCmd)
+}
@@ -3,7 +3,7 @@ package cmd
 import (
 	"shell-history-client/internal/buffer"
 	"shell-history-client/internal/client"
-	"shell-history-client/internal/models"
+	"shell-history-client/internal/flusher"
 
 	"github.com/spf13/cobra"
 )
@@ -11,8 +11,12 @@ import (
 var flushCmd = &cobra.Command{
 	Use:   "flush",
 	Short: "Flush local command buffer",
+	Long: `Send buffered commands to the server in batches, same as
+"shell-history daemon" does on a timer. A batch that fails is left in
+the buffer (along with everything after it, to preserve order) for the
+next flush or daemon run to retry.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		bm, err := buffer.NewBufferManager()
+		bm, err := buffer.NewBufferManagerWithEncryption(cfg.EncryptBuffer)
 		if err != nil {
 			cmd.Printf("Error accessing buffer: %v\n", err)
 			return
@@ -23,28 +27,32 @@ var flushCmd = &cobra.Command{
 			return
 		}
 
-		apiClient := client.NewAPIClient(cfg.ServerURL, cfg.APIKey)
+		apiClient := client.NewAPIClientFromConfig(cfg)
+		total := len(bm.Commands)
 		sent := 0
-		var remaining []models.Command
-
-		for _, cmdModel := range bm.Commands {
-			err := apiClient.Capture(cmdModel)
-			if err != nil {
-				remaining = append(remaining, cmdModel)
-			} else {
-				sent++
+
+		for len(bm.Commands) > 0 {
+			batch := bm.Commands
+			if len(batch) > flusher.DefaultBatchSize {
+				batch = batch[:flusher.DefaultBatchSize]
 			}
-		}
 
-		bm.Commands = remaining
-		err = bm.Save()
-		if err != nil {
-			cmd.Printf("Error saving remaining buffer: %v\n", err)
+			if err := apiClient.CaptureBatch(batch); err != nil {
+				cmd.Printf("Error sending batch: %v\n", err)
+				break
+			}
+
+			sent += len(batch)
+			bm.Commands = bm.Commands[len(batch):]
+			if err := bm.Save(); err != nil {
+				cmd.Printf("Error saving remaining buffer: %v\n", err)
+				break
+			}
 		}
 
-		cmd.Printf("Sent %d buffered commands\n", sent)
-		if len(remaining) > 0 {
-			cmd.Printf("%d commands still buffered due to errors\n", len(remaining))
+		cmd.Printf("Sent %d of %d buffered commands\n", sent, total)
+		if len(bm.Commands) > 0 {
+			cmd.Printf("%d commands still buffered due to errors\n", len(bm.Commands))
 		}
 	},
 }
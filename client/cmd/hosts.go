@@ -0,0 +1,207 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"shell-history-client/internal/client"
+	"shell-history-client/internal/models"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	hostsLastSeen      string
+	hostsUser          string
+	hostsJSON          bool
+	hostsPurgeHistory  bool
+	hostsInactiveSince string
+	hostsPruneDryRun   bool
+	hostsPruneYes      bool
+)
+
+var hostsCmd = &cobra.Command{
+	Use:   "hosts",
+	Short: "Manage machines reporting command history",
+}
+
+var hostsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List hosts that have reported command history",
+	Run: func(cmd *cobra.Command, args []string) {
+		seenSince, err := parseTimeFlag(hostsLastSeen)
+		if err != nil {
+			cmd.Printf("Error: --last-seen %v\n", err)
+			return
+		}
+
+		apiClient := client.NewAPIClientFromConfig(cfg)
+		hosts, err := apiClient.ListHosts(client.HostListParams{
+			Username:  hostsUser,
+			SeenSince: seenSince,
+		})
+		if err != nil {
+			cmd.Printf("Error listing hosts: %v\n", err)
+			return
+		}
+
+		printHosts(cmd, hosts)
+	},
+}
+
+var hostsShowCmd = &cobra.Command{
+	Use:   "show <hostname>",
+	Short: "Show details for a single host",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		apiClient := client.NewAPIClientFromConfig(cfg)
+		hosts, err := apiClient.ListHosts(client.HostListParams{Hostname: args[0]})
+		if err != nil {
+			cmd.Printf("Error fetching host: %v\n", err)
+			return
+		}
+		if len(hosts) == 0 {
+			cmd.Printf("No host named %q\n", args[0])
+			return
+		}
+
+		printHosts(cmd, hosts)
+	},
+}
+
+var hostsRenameCmd = &cobra.Command{
+	Use:   "rename <old> <new>",
+	Short: "Rename a host as seen by the server",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		apiClient := client.NewAPIClientFromConfig(cfg)
+		if err := apiClient.RenameHost(args[0], args[1]); err != nil {
+			cmd.Printf("Error renaming host: %v\n", err)
+			return
+		}
+		cmd.Printf("Renamed host %q to %q\n", args[0], args[1])
+	},
+}
+
+var hostsDeleteCmd = &cobra.Command{
+	Use:   "delete <hostname>",
+	Short: "Delete a host",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		apiClient := client.NewAPIClientFromConfig(cfg)
+		if err := apiClient.DeleteHost(args[0], hostsPurgeHistory); err != nil {
+			cmd.Printf("Error deleting host: %v\n", err)
+			return
+		}
+		cmd.Printf("Deleted host %q\n", args[0])
+	},
+}
+
+var hostsPruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Delete hosts that haven't reported since a given time",
+	Long: `Delete every host that hasn't reported since --inactive-since,
+listing them and asking for confirmation first since a typo in
+--inactive-since (e.g. "3d" instead of "30d") can otherwise match and
+delete far more than intended. Pass --dry-run to only see the list, or
+--yes to skip the prompt for scripted use.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if hostsInactiveSince == "" {
+			cmd.Println("Error: --inactive-since is required")
+			return
+		}
+		inactiveSince, err := parseTimeFlag(hostsInactiveSince)
+		if err != nil {
+			cmd.Printf("Error: --inactive-since %v\n", err)
+			return
+		}
+
+		apiClient := client.NewAPIClientFromConfig(cfg)
+		stale, err := apiClient.ListHosts(client.HostListParams{InactiveSince: inactiveSince})
+		if err != nil {
+			cmd.Printf("Error listing hosts: %v\n", err)
+			return
+		}
+
+		if len(stale) == 0 {
+			cmd.Println("No stale hosts to prune.")
+			return
+		}
+
+		cmd.Printf("%d host(s) match --inactive-since %s:\n", len(stale), hostsInactiveSince)
+		for _, h := range stale {
+			cmd.Printf("  %s  last seen: %s\n", h.Hostname, h.LastSeen)
+		}
+		if hostsPurgeHistory {
+			cmd.Println("--purge-history is set: captured command history for these hosts will also be deleted.")
+		}
+
+		if hostsPruneDryRun {
+			cmd.Println("Dry run: no hosts were deleted.")
+			return
+		}
+
+		if !hostsPruneYes && !confirmPrompt(cmd, fmt.Sprintf("Delete %d host(s)? [y/N] ", len(stale))) {
+			cmd.Println("Aborted.")
+			return
+		}
+
+		for _, h := range stale {
+			if err := apiClient.DeleteHost(h.Hostname, hostsPurgeHistory); err != nil {
+				cmd.Printf("Error deleting %q: %v\n", h.Hostname, err)
+				continue
+			}
+			cmd.Printf("Pruned %q (last seen: %s)\n", h.Hostname, h.LastSeen)
+		}
+	},
+}
+
+// confirmPrompt prints prompt, reads a line from stdin, and reports
+// whether it was an affirmative response ("y" or "yes", case-insensitive).
+func confirmPrompt(cmd *cobra.Command, prompt string) bool {
+	cmd.Print(prompt)
+	scanner := bufio.NewScanner(os.Stdin)
+	if !scanner.Scan() {
+		return false
+	}
+	answer := strings.ToLower(strings.TrimSpace(scanner.Text()))
+	return answer == "y" || answer == "yes"
+}
+
+func printHosts(cmd *cobra.Command, hosts []models.Host) {
+	if hostsJSON {
+		data, err := json.MarshalIndent(hosts, "", "  ")
+		if err != nil {
+			cmd.Printf("Error: %v\n", err)
+			return
+		}
+		cmd.Println(string(data))
+		return
+	}
+
+	for _, h := range hosts {
+		cmd.Printf("%s  last seen: %s  last user: %s  commands: %d\n", h.Hostname, h.LastSeen, h.LastUser, h.CommandCount)
+	}
+}
+
+func init() {
+	hostsListCmd.Flags().StringVar(&hostsLastSeen, "last-seen", "", "Only hosts seen at or after this time (RFC3339 or relative, e.g. \"24h\", \"7d\")")
+	hostsListCmd.Flags().StringVar(&hostsUser, "user", "", "Only hosts last used by this user")
+	hostsListCmd.Flags().BoolVar(&hostsJSON, "json", false, "Print as JSON instead of a table")
+
+	hostsDeleteCmd.Flags().BoolVar(&hostsPurgeHistory, "purge-history", false, "Also delete the host's captured commands")
+	hostsPruneCmd.Flags().StringVar(&hostsInactiveSince, "inactive-since", "", "Prune hosts not seen since this time (RFC3339 or relative, e.g. \"30d\")")
+	hostsPruneCmd.Flags().BoolVar(&hostsPurgeHistory, "purge-history", false, "Also delete pruned hosts' captured commands")
+	hostsPruneCmd.Flags().BoolVar(&hostsPruneDryRun, "dry-run", false, "List hosts that would be pruned without deleting anything")
+	hostsPruneCmd.Flags().BoolVar(&hostsPruneYes, "yes", false, "Skip the confirmation prompt")
+
+	hostsCmd.AddCommand(hostsListCmd)
+	hostsCmd.AddCommand(hostsShowCmd)
+	hostsCmd.AddCommand(hostsRenameCmd)
+	hostsCmd.AddCommand(hostsDeleteCmd)
+	hostsCmd.AddCommand(hostsPruneCmd)
+	rootCmd.AddCommand(hostsCmd)
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"shell-history-client/internal/oidc"
+
+	"github.com/spf13/cobra"
+)
+
+var loginClientID string
+
+var loginCmd = &cobra.Command{
+	Use:   "login",
+	Short: "Authenticate via your identity provider instead of a static API key",
+	Run: func(cmd *cobra.Command, args []string) {
+		issuerURL := cfg.IssuerURL
+		if issuerURL == "" {
+			issuerURL = cfg.ServerURL
+		}
+		clientID := loginClientID
+		if clientID == "" {
+			clientID = cfg.ClientID
+		}
+		if clientID == "" {
+			cmd.Println("Error: no client ID configured; pass --client-id or set one with 'shell-history config set-client-id'")
+			return
+		}
+
+		auth, err := oidc.StartDeviceAuth(issuerURL, clientID)
+		if err != nil {
+			cmd.Printf("Error starting login: %v\n", err)
+			return
+		}
+
+		cmd.Printf("To continue, open %s in a browser and enter code: %s\n", auth.VerificationURI, auth.UserCode)
+		if auth.VerificationURIComplete != "" {
+			cmd.Printf("Or open this link directly: %s\n", auth.VerificationURIComplete)
+		}
+
+		tok, err := oidc.PollToken(issuerURL, clientID, auth)
+		if err != nil {
+			cmd.Printf("Error completing login: %v\n", err)
+			return
+		}
+
+		cfg.IssuerURL = issuerURL
+		cfg.ClientID = clientID
+		cfg.AccessToken = tok.AccessToken
+		cfg.RefreshToken = tok.RefreshToken
+
+		if err := saveConfig(); err != nil {
+			cmd.Printf("Error saving configuration: %v\n", err)
+			return
+		}
+
+		cmd.Printf("Logged in successfully as client %q\n", clientID)
+	},
+}
+
+func init() {
+	loginCmd.Flags().StringVar(&loginClientID, "client-id", "", "OAuth2 client ID (defaults to the configured one)")
+	rootCmd.AddCommand(loginCmd)
+}
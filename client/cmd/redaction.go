@@ -2,6 +2,8 @@ package cmd
 
 import (
 	"fmt"
+	"regexp"
+	"strings"
 
 	"shell-history-client/internal/redaction"
 
@@ -12,6 +14,8 @@ var (
 	ruleName        string
 	rulePattern     string
 	ruleReplacement string
+	rulePriority    int
+	ruleTags        []string
 )
 
 var redactionCmd = &cobra.Command{
@@ -35,9 +39,17 @@ var redactionListCmd = &cobra.Command{
 
 		fmt.Printf("Configured redaction rules (%d):\n\n", len(cfg.RedactionRules))
 		for i, rule := range cfg.RedactionRules {
-			fmt.Printf("%d. %s\n", i+1, rule.Name)
+			status := "enabled"
+			if !rule.Enabled {
+				status = "disabled"
+			}
+			fmt.Printf("%d. %s (priority %d, %s)\n", i+1, rule.Name, rule.Priority, status)
 			fmt.Printf("   Pattern:     %s\n", rule.Pattern)
-			fmt.Printf("   Replacement: %s\n\n", rule.Replacement)
+			fmt.Printf("   Replacement: %s\n", rule.Replacement)
+			if len(rule.Tags) > 0 {
+				fmt.Printf("   Tags:        %s\n", strings.Join(rule.Tags, ", "))
+			}
+			fmt.Println()
 		}
 	},
 }
@@ -66,6 +78,11 @@ Examples:
 			ruleReplacement = "[REDACTED]"
 		}
 
+		if _, err := regexp.Compile("(?i)" + rulePattern); err != nil {
+			fmt.Printf("Error: %q is not a valid regular expression: %v\n", rulePattern, err)
+			return
+		}
+
 		// Check for duplicate name
 		for _, rule := range cfg.RedactionRules {
 			if rule.Name == ruleName {
@@ -78,6 +95,9 @@ Examples:
 			Name:        ruleName,
 			Pattern:     rulePattern,
 			Replacement: ruleReplacement,
+			Priority:    rulePriority,
+			Enabled:     true,
+			Tags:        ruleTags,
 		}
 
 		cfg.RedactionRules = append(cfg.RedactionRules, newRule)
@@ -128,6 +148,8 @@ func init() {
 	redactionAddCmd.Flags().StringVar(&ruleName, "name", "", "Name for the redaction rule (required)")
 	redactionAddCmd.Flags().StringVar(&rulePattern, "pattern", "", "Regex pattern to match (required)")
 	redactionAddCmd.Flags().StringVar(&ruleReplacement, "replacement", "[REDACTED]", "Replacement text")
+	redactionAddCmd.Flags().IntVar(&rulePriority, "priority", 0, "Priority; lower runs first (see 'redaction reorder')")
+	redactionAddCmd.Flags().StringArrayVar(&ruleTags, "tag", nil, "Tag to attach, for grouping with 'redaction export --tag' (repeatable)")
 
 	redactionCmd.AddCommand(redactionListCmd)
 	redactionCmd.AddCommand(redactionAddCmd)
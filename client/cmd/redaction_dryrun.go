@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+
+	"shell-history-client/internal/redaction"
+
+	"github.com/spf13/cobra"
+)
+
+var testRuleName string
+
+var redactionTestCmd = &cobra.Command{
+	Use:   "test [command]",
+	Short: "Dry-run the configured redaction rules against a command",
+	Long: `Runs the full redaction chain (enabled presets, then user rules)
+against the given command line, or against stdin if no argument is given,
+and prints whether each rule matched along with the final redacted output
+that would be sent to the server.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		var input string
+		if len(args) == 1 {
+			input = args[0]
+		} else {
+			scanner := bufio.NewScanner(os.Stdin)
+			if scanner.Scan() {
+				input = scanner.Text()
+			}
+		}
+
+		redactor := redaction.NewRedactorWithPresets(cfg.RedactionPresets, cfg.RedactionRules)
+		traces := redactor.TraceVerbose(input)
+
+		final := input
+		for _, trace := range traces {
+			final = trace.Result
+
+			if testRuleName != "" && trace.Name != testRuleName {
+				continue
+			}
+			status := "no match"
+			if trace.Matched {
+				status = "matched"
+			}
+			fmt.Printf("%-30s %s\n", trace.Name, status)
+		}
+
+		fmt.Printf("\nWould send: %s\n", final)
+	},
+}
+
+func init() {
+	redactionTestCmd.Flags().StringVar(&testRuleName, "rule", "", "Only show the result of this one rule (by name)")
+	redactionCmd.AddCommand(redactionTestCmd)
+}
@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"shell-history-client/internal/redaction"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportFile    string
+	exportTag     string
+	importMerge   bool
+	importReplace bool
+)
+
+var redactionExportCmd = &cobra.Command{
+	Use:   "export [--file path] [--tag name]",
+	Short: "Export configured redaction rules as a versioned JSON document",
+	Long: `Writes the current redaction rules to a shareable JSON document
+that teammates can check into a repo and load with "redaction import",
+instead of hand-copying config.json fragments.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		doc := redaction.RuleDocument{Version: redaction.DocumentVersion, Rules: cfg.RedactionRules}
+		doc = doc.FilterByTag(exportTag)
+
+		data, err := json.MarshalIndent(doc, "", "  ")
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		if exportFile == "" {
+			fmt.Println(string(data))
+			return
+		}
+		if err := os.WriteFile(exportFile, data, 0644); err != nil {
+			fmt.Printf("Error writing %s: %v\n", exportFile, err)
+			return
+		}
+		fmt.Printf("Exported %d rule(s) to %s\n", len(doc.Rules), exportFile)
+	},
+}
+
+var redactionImportCmd = &cobra.Command{
+	Use:   "import <file|url> [--merge|--replace]",
+	Short: "Import redaction rules from a document produced by \"redaction export\"",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if importMerge && importReplace {
+			fmt.Println("Error: --merge and --replace are mutually exclusive")
+			return
+		}
+
+		data, err := readRuleDocument(args[0])
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		var doc redaction.RuleDocument
+		if err := json.Unmarshal(data, &doc); err != nil {
+			fmt.Printf("Error: %s is not a valid rule document: %v\n", args[0], err)
+			return
+		}
+
+		if importReplace {
+			cfg.RedactionRules = doc.Rules
+		} else {
+			cfg.RedactionRules = redaction.MergeRules(cfg.RedactionRules, doc.Rules)
+		}
+
+		if err := saveConfig(); err != nil {
+			fmt.Printf("Error saving config: %v\n", err)
+			return
+		}
+
+		fmt.Printf("Imported %d rule(s) from %s\n", len(doc.Rules), args[0])
+	},
+}
+
+// readRuleDocument reads raw JSON from a local file path or, if source
+// looks like one, an http(s) URL.
+func readRuleDocument(source string) ([]byte, error) {
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		httpClient := &http.Client{Timeout: 10 * time.Second}
+		resp, err := httpClient.Get(source)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("unexpected status %s", resp.Status)
+		}
+		return io.ReadAll(resp.Body)
+	}
+	return os.ReadFile(source)
+}
+
+func init() {
+	redactionExportCmd.Flags().StringVar(&exportFile, "file", "", "Write to this file instead of stdout")
+	redactionExportCmd.Flags().StringVar(&exportTag, "tag", "", "Only export rules carrying this tag")
+	redactionImportCmd.Flags().BoolVar(&importMerge, "merge", false, "Merge with existing rules by name (default)")
+	redactionImportCmd.Flags().BoolVar(&importReplace, "replace", false, "Replace all existing rules with the imported set")
+
+	redactionCmd.AddCommand(redactionExportCmd)
+	redactionCmd.AddCommand(redactionImportCmd)
+}
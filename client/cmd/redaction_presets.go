@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"shell-history-client/internal/redaction/presets"
+
+	"github.com/spf13/cobra"
+)
+
+var redactionPresetsCmd = &cobra.Command{
+	Use:   "presets",
+	Short: "Manage built-in redaction presets",
+	Long: `Enable curated, versioned presets (AWS keys, GitHub tokens, JWTs,
+SSH private keys, credit card numbers, etc.) instead of hand-writing every
+regex.
+
+Enabled presets are applied ahead of any rules added with
+"shell-history redaction add".`,
+}
+
+var redactionPresetsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List built-in redaction presets and whether they're enabled",
+	Run: func(cmd *cobra.Command, args []string) {
+		enabled := enabledPresets()
+
+		for _, name := range presets.Names() {
+			status := "disabled"
+			if enabled[name] {
+				status = "enabled"
+			}
+			preset, _ := presets.Get(name)
+			fmt.Printf("%s (v%s, %d rules) - %s\n", name, preset.Version, len(preset.Rules), status)
+		}
+	},
+}
+
+var redactionPresetsShowCmd = &cobra.Command{
+	Use:   "show <name>",
+	Short: "Show the rules a built-in preset applies",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		preset, ok := presets.Get(args[0])
+		if !ok {
+			fmt.Printf("Error: unknown preset %q (known presets: %s)\n", args[0], strings.Join(presets.Names(), ", "))
+			return
+		}
+
+		fmt.Printf("%s (v%s)\n", preset.Name, preset.Version)
+		for _, rule := range preset.Rules {
+			fmt.Printf("  %s: %s -> %s\n", rule.Name, rule.Pattern, rule.Replacement)
+		}
+	},
+}
+
+var redactionPresetsEnableCmd = &cobra.Command{
+	Use:   "enable <name>[,<name>...]",
+	Short: "Enable one or more built-in redaction presets",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		known := presetNameSet()
+		enabled := enabledPresets()
+
+		for _, name := range strings.Split(args[0], ",") {
+			if !known[name] {
+				fmt.Printf("Error: unknown preset %q (known presets: %s)\n", name, strings.Join(presets.Names(), ", "))
+				return
+			}
+			enabled[name] = true
+		}
+
+		if err := savePresets(enabled); err != nil {
+			fmt.Printf("Error saving config: %v\n", err)
+			return
+		}
+
+		fmt.Printf("Enabled preset(s): %s\n", args[0])
+	},
+}
+
+var redactionPresetsDisableCmd = &cobra.Command{
+	Use:   "disable <name>[,<name>...]",
+	Short: "Disable one or more built-in redaction presets",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		enabled := enabledPresets()
+
+		for _, name := range strings.Split(args[0], ",") {
+			delete(enabled, name)
+		}
+
+		if err := savePresets(enabled); err != nil {
+			fmt.Printf("Error saving config: %v\n", err)
+			return
+		}
+
+		fmt.Printf("Disabled preset(s): %s\n", args[0])
+	},
+}
+
+func presetNameSet() map[string]bool {
+	known := make(map[string]bool)
+	for _, name := range presets.Names() {
+		known[name] = true
+	}
+	return known
+}
+
+func enabledPresets() map[string]bool {
+	enabled := make(map[string]bool, len(cfg.RedactionPresets))
+	for _, name := range cfg.RedactionPresets {
+		enabled[name] = true
+	}
+	return enabled
+}
+
+func savePresets(enabled map[string]bool) error {
+	cfg.RedactionPresets = cfg.RedactionPresets[:0]
+	for name := range enabled {
+		cfg.RedactionPresets = append(cfg.RedactionPresets, name)
+	}
+	return saveConfig()
+}
+
+func init() {
+	redactionPresetsCmd.AddCommand(redactionPresetsListCmd)
+	redactionPresetsCmd.AddCommand(redactionPresetsShowCmd)
+	redactionPresetsCmd.AddCommand(redactionPresetsEnableCmd)
+	redactionPresetsCmd.AddCommand(redactionPresetsDisableCmd)
+	redactionCmd.AddCommand(redactionPresetsCmd)
+}
@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	reorderBefore   string
+	reorderPriority int
+)
+
+var redactionEnableCmd = &cobra.Command{
+	Use:   "enable <name>",
+	Short: "Enable a redaction rule by name",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		setRuleEnabled(cmd, args[0], true)
+	},
+}
+
+var redactionDisableCmd = &cobra.Command{
+	Use:   "disable <name>",
+	Short: "Disable a redaction rule by name without removing it",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		setRuleEnabled(cmd, args[0], false)
+	},
+}
+
+func setRuleEnabled(cmd *cobra.Command, name string, enabled bool) {
+	i := findRuleIndex(name)
+	if i == -1 {
+		fmt.Printf("Error: No rule named %q found\n", name)
+		return
+	}
+
+	cfg.RedactionRules[i].Enabled = enabled
+	if err := saveConfig(); err != nil {
+		fmt.Printf("Error saving config: %v\n", err)
+		return
+	}
+
+	state := "disabled"
+	if enabled {
+		state = "enabled"
+	}
+	fmt.Printf("Rule %q %s\n", name, state)
+}
+
+var redactionReorderCmd = &cobra.Command{
+	Use:   "reorder <name>",
+	Short: "Change a rule's priority, either to a fixed number or relative to another rule",
+	Long: `Rules run in ascending priority order, ties broken by the order they
+were added. Use --priority to set an absolute priority, or --before to
+place the rule immediately ahead of another rule's priority.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		name := args[0]
+		i := findRuleIndex(name)
+		if i == -1 {
+			fmt.Printf("Error: No rule named %q found\n", name)
+			return
+		}
+
+		switch {
+		case cmd.Flags().Changed("priority"):
+			cfg.RedactionRules[i].Priority = reorderPriority
+		case cmd.Flags().Changed("before"):
+			j := findRuleIndex(reorderBefore)
+			if j == -1 {
+				fmt.Printf("Error: No rule named %q found\n", reorderBefore)
+				return
+			}
+			cfg.RedactionRules[i].Priority = cfg.RedactionRules[j].Priority - 1
+		default:
+			fmt.Println("Error: one of --priority or --before is required")
+			return
+		}
+
+		if err := saveConfig(); err != nil {
+			fmt.Printf("Error saving config: %v\n", err)
+			return
+		}
+
+		fmt.Printf("Rule %q priority set to %d\n", name, cfg.RedactionRules[i].Priority)
+	},
+}
+
+func findRuleIndex(name string) int {
+	for i, rule := range cfg.RedactionRules {
+		if rule.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func init() {
+	redactionReorderCmd.Flags().StringVar(&reorderBefore, "before", "", "Place this rule immediately ahead of the named rule")
+	redactionReorderCmd.Flags().IntVar(&reorderPriority, "priority", 0, "Set an absolute priority (lower runs first)")
+
+	redactionCmd.AddCommand(redactionEnableCmd)
+	redactionCmd.AddCommand(redactionDisableCmd)
+	redactionCmd.AddCommand(redactionReorderCmd)
+}
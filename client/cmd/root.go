@@ -9,8 +9,9 @@ import (
 )
 
 var (
-	cfg       config.Config
-	serverURL string
+	cfg        config.Config
+	serverURL  string
+	socketPath string
 )
 
 var rootCmd = &cobra.Command{
@@ -21,6 +22,9 @@ var rootCmd = &cobra.Command{
 		if serverURL != "" {
 			cfg.ServerURL = serverURL
 		}
+		if socketPath != "" {
+			cfg.Socket = socketPath
+		}
 	},
 }
 
@@ -33,6 +37,7 @@ func Execute() {
 
 func init() {
 	rootCmd.PersistentFlags().StringVar(&serverURL, "server", "", "Server URL")
+	rootCmd.PersistentFlags().StringVar(&socketPath, "socket", "", "Unix domain socket to dial instead of the server URL (e.g. /var/run/shell-history.sock)")
 }
 
 func SetArgs(args []string) {
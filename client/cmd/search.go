@@ -1,51 +1,280 @@
 package cmd
 
 import (
+	"encoding/csv"
+	"encoding/json"
 	"fmt"
+	"path/filepath"
 	"shell-history-client/internal/client"
+	"shell-history-client/internal/models"
+	"shell-history-client/internal/tui"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 )
 
 var (
-	searchHostname string
-	searchUser     string
-	searchLimit    int
+	searchHostname        string
+	searchUser            string
+	searchPage            int
+	searchPageSize        int
+	searchLimit           int
+	searchSince           string
+	searchUntil           string
+	searchExitCode        int
+	searchNonZeroExitCode bool
+	searchOutput          string
+	searchAll             bool
+	searchInteractive     bool
+	searchFields          []string
 )
 
+// fieldValue returns the value of a structured field on cmd, used for
+// --field filters. Only fields that aren't already covered by a dedicated
+// flag (--hostname, --user, ...) are exposed here.
+func fieldValue(c models.Command, field string) (string, bool) {
+	switch field {
+	case "cwd":
+		return c.CWD, true
+	case "shell":
+		return c.Shell, true
+	case "tty":
+		return c.TTY, true
+	case "git_repo":
+		return c.GitRepo, true
+	case "git_branch":
+		return c.GitBranch, true
+	case "git_commit":
+		return c.GitCommit, true
+	case "session_id":
+		return c.SessionID, true
+	default:
+		return "", false
+	}
+}
+
+// matchesFieldFilters reports whether cmd satisfies every "field:glob"
+// filter passed via --field, using filepath.Match-style globs (so "foo*"
+// matches anything starting with "foo"). This runs client-side since the
+// search API doesn't expose arbitrary structured-field filtering.
+func matchesFieldFilters(c models.Command, filters []string) (bool, error) {
+	for _, filter := range filters {
+		field, pattern, ok := strings.Cut(filter, ":")
+		if !ok {
+			return false, fmt.Errorf("--field %q is not in field:pattern form", filter)
+		}
+		value, known := fieldValue(c, field)
+		if !known {
+			return false, fmt.Errorf("--field: unknown field %q", field)
+		}
+		matched, err := filepath.Match(pattern, value)
+		if err != nil {
+			return false, fmt.Errorf("--field %q: %v", filter, err)
+		}
+		if !matched {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
 var searchCmd = &cobra.Command{
 	Use:   "search [query]",
 	Short: "Search command history",
-	Args:  cobra.ExactArgs(1),
+	Args:  cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		query := args[0]
-		apiClient := client.NewAPIClient(cfg.ServerURL, cfg.APIKey)
+		query := ""
+		if len(args) > 0 {
+			query = args[0]
+		}
+		apiClient := client.NewAPIClientFromConfig(cfg)
+
+		if searchInteractive {
+			runInteractiveSearch(cmd, apiClient)
+			return
+		}
 
-		results, err := apiClient.Search(query, searchHostname, searchUser, searchLimit)
+		if query == "" {
+			cmd.Println("Error: a query is required (or pass --interactive to browse)")
+			return
+		}
+
+		pageSize := searchPageSize
+		if cmd.Flags().Changed("limit") {
+			pageSize = searchLimit
+		}
+
+		since, err := parseTimeFlag(searchSince)
 		if err != nil {
-			cmd.Printf("Error searching: %v\n", err)
+			cmd.Printf("Error: --since %v\n", err)
 			return
 		}
+		until, err := parseTimeFlag(searchUntil)
+		if err != nil {
+			cmd.Printf("Error: --until %v\n", err)
+			return
+		}
+
+		params := client.SearchParams{
+			Query:           query,
+			Hostname:        searchHostname,
+			Username:        searchUser,
+			Page:            searchPage,
+			PageSize:        pageSize,
+			Since:           since,
+			Until:           until,
+			NonZeroExitCode: searchNonZeroExitCode,
+		}
+		if cmd.Flags().Changed("exit-code") {
+			params.ExitCode = &searchExitCode
+		}
 
-		for _, cmdRes := range results {
-			timestamp := cmdRes.Timestamp
-			if len(timestamp) > 19 {
-				timestamp = timestamp[:19]
+		var results []models.Command
+		for {
+			page, err := apiClient.Search(params)
+			if err != nil {
+				cmd.Printf("Error searching: %v\n", err)
+				return
 			}
-			timestamp = strings.Replace(timestamp, "T", " ", 1)
-			exitStatus := ""
-			if cmdRes.ExitCode != nil {
-				exitStatus = fmt.Sprintf(" (exit: %d)", *cmdRes.ExitCode)
+			for _, item := range page.Items {
+				matched, err := matchesFieldFilters(item, searchFields)
+				if err != nil {
+					cmd.Printf("Error: %v\n", err)
+					return
+				}
+				if matched {
+					results = append(results, item)
+				}
 			}
-			cmd.Printf("[%s] %s: %s%s\n", timestamp, cmdRes.Hostname, cmdRes.Command, exitStatus)
+
+			if !searchAll || page.NextCursor == "" {
+				break
+			}
+			params.Cursor = page.NextCursor
+		}
+
+		if err := printResults(cmd, results, searchOutput); err != nil {
+			cmd.Printf("Error: %v\n", err)
 		}
 	},
 }
 
+// runInteractiveSearch fetches a window of results and hands them to the
+// full-screen picker. The chosen command is printed to stdout so shell
+// integrations can capture it with command substitution (see
+// shell/search.zsh and shell/search.bash).
+func runInteractiveSearch(cmd *cobra.Command, apiClient *client.APIClient) {
+	page, err := apiClient.Search(client.SearchParams{
+		Hostname: searchHostname,
+		Username: searchUser,
+		PageSize: tui.FetchWindowSize,
+	})
+	if err != nil {
+		cmd.PrintErrf("Error searching: %v\n", err)
+		return
+	}
+
+	selected, ok := tui.Run(page.Items)
+	if !ok {
+		return
+	}
+	fmt.Println(selected)
+}
+
+// parseTimeFlag accepts an RFC3339 timestamp or a relative duration like
+// "24h" or "7d" (time.ParseDuration plus a "d" suffix for days), returning
+// an RFC3339 timestamp in the former case or now minus the duration in the
+// latter. An empty string returns an empty string.
+func parseTimeFlag(raw string) (string, error) {
+	if raw == "" {
+		return "", nil
+	}
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return t.UTC().Format(time.RFC3339), nil
+	}
+
+	d, err := parseRelativeDuration(raw)
+	if err != nil {
+		return "", fmt.Errorf("%q is not an RFC3339 timestamp or a relative duration like \"24h\" or \"7d\"", raw)
+	}
+	return time.Now().Add(-d).UTC().Format(time.RFC3339), nil
+}
+
+func parseRelativeDuration(raw string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(raw, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, err
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	return time.ParseDuration(raw)
+}
+
+// printResults renders results in the requested output format: "table"
+// (the default), "json", "csv", or "tsv".
+func printResults(cmd *cobra.Command, results []models.Command, output string) error {
+	switch output {
+	case "", "table":
+		printTable(cmd, results)
+		return nil
+	case "json":
+		data, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return err
+		}
+		cmd.Println(string(data))
+		return nil
+	case "csv", "tsv":
+		w := csv.NewWriter(cmd.OutOrStdout())
+		if output == "tsv" {
+			w.Comma = '\t'
+		}
+		w.Write([]string{"timestamp", "hostname", "username", "exit_code", "command"})
+		for _, r := range results {
+			exitStatus := ""
+			if r.ExitCode != nil {
+				exitStatus = strconv.Itoa(*r.ExitCode)
+			}
+			w.Write([]string{r.Timestamp, r.Hostname, r.Username, exitStatus, r.Command})
+		}
+		w.Flush()
+		return w.Error()
+	default:
+		return fmt.Errorf("unknown output format %q (want table, json, csv, or tsv)", output)
+	}
+}
+
+func printTable(cmd *cobra.Command, results []models.Command) {
+	for _, cmdRes := range results {
+		timestamp := cmdRes.Timestamp
+		if len(timestamp) > 19 {
+			timestamp = timestamp[:19]
+		}
+		timestamp = strings.Replace(timestamp, "T", " ", 1)
+		exitStatus := ""
+		if cmdRes.ExitCode != nil {
+			exitStatus = fmt.Sprintf(" (exit: %d)", *cmdRes.ExitCode)
+		}
+		cmd.Printf("[%s] %s: %s%s\n", timestamp, cmdRes.Hostname, cmdRes.Command, exitStatus)
+	}
+}
+
 func init() {
 	searchCmd.Flags().StringVar(&searchHostname, "hostname", "", "Filter by hostname")
 	searchCmd.Flags().StringVar(&searchUser, "user", "", "Filter by username")
-	searchCmd.Flags().IntVar(&searchLimit, "limit", 50, "Max results")
+	searchCmd.Flags().IntVar(&searchPage, "page", 0, "Page number to fetch (ignored when --all is set)")
+	searchCmd.Flags().IntVar(&searchPageSize, "page-size", 50, "Results per page")
+	searchCmd.Flags().IntVar(&searchLimit, "limit", 50, "Alias for --page-size")
+	searchCmd.Flags().StringVar(&searchSince, "since", "", "Only commands at or after this time (RFC3339 or relative, e.g. \"24h\", \"7d\")")
+	searchCmd.Flags().StringVar(&searchUntil, "until", "", "Only commands at or before this time (RFC3339 or relative, e.g. \"24h\", \"7d\")")
+	searchCmd.Flags().IntVar(&searchExitCode, "exit-code", 0, "Only commands with this exact exit code")
+	searchCmd.Flags().BoolVar(&searchNonZeroExitCode, "exit-code-nonzero", false, "Only commands with a non-zero exit code")
+	searchCmd.Flags().StringVar(&searchOutput, "output", "table", "Output format: table, json, csv, or tsv")
+	searchCmd.Flags().BoolVar(&searchAll, "all", false, "Follow the next-page cursor until all matching results are fetched")
+	searchCmd.Flags().BoolVarP(&searchInteractive, "interactive", "i", false, "Open a full-screen fuzzy picker (Ctrl-R replacement)")
+	searchCmd.Flags().StringArrayVar(&searchFields, "field", nil, "Filter on a structured field as field:glob (e.g. cwd:/home/me/*), repeatable")
 	rootCmd.AddCommand(searchCmd)
 }
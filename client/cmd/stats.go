@@ -11,11 +11,11 @@ var statsCmd = &cobra.Command{
 	Use:   "stats",
 	Short: "Show usage statistics",
 	Run: func(cmd *cobra.Command, args []string) {
-		apiClient := client.NewAPIClient(cfg.ServerURL, cfg.APIKey)
+		apiClient := client.NewAPIClientFromConfig(cfg)
 		stats, err := apiClient.GetStats()
 		if err != nil {
 			// fallback to local stats
-			bm, err := buffer.NewBufferManager()
+			bm, err := buffer.NewBufferManagerWithEncryption(cfg.EncryptBuffer)
 			if err != nil {
 				cmd.Printf("Error getting local stats: %v\n", err)
 				return
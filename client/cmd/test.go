@@ -10,7 +10,7 @@ var testCmd = &cobra.Command{
 	Use:   "test",
 	Short: "Test connection to server",
 	Run: func(cmd *cobra.Command, args []string) {
-		apiClient := client.NewAPIClient(cfg.ServerURL, cfg.APIKey)
+		apiClient := client.NewAPIClientFromConfig(cfg)
 		health, err := apiClient.HealthCheck()
 		if err != nil {
 			cmd.Printf("✗ Cannot connect to %s\n", cfg.ServerURL)
@@ -1,7 +1,9 @@
 package buffer
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
 	"shell-history-client/internal/models"
@@ -10,9 +12,25 @@ import (
 type BufferManager struct {
 	BufferPath string
 	Commands   []models.Command
+
+	// encrypt, when true, seals the buffer at rest with AES-256-GCM under
+	// a key generated on first use (see crypto.go). Existing plaintext
+	// buffers are still read transparently and get encrypted on the next
+	// Save.
+	encrypt bool
+	key     []byte
 }
 
+// NewBufferManager creates a BufferManager that stores commands as
+// plaintext JSON, matching the historical on-disk format.
 func NewBufferManager() (*BufferManager, error) {
+	return NewBufferManagerWithEncryption(false)
+}
+
+// NewBufferManagerWithEncryption creates a BufferManager that, when encrypt
+// is true, seals the on-disk buffer with a key stored in
+// ~/.config/shell-history/buffer.key (mode 0600).
+func NewBufferManagerWithEncryption(encrypt bool) (*BufferManager, error) {
 	home, err := os.UserHomeDir()
 	if err != nil {
 		return nil, err
@@ -26,6 +44,15 @@ func NewBufferManager() (*BufferManager, error) {
 	bm := &BufferManager{
 		BufferPath: filepath.Join(bufferDir, "buffer.json"),
 		Commands:   []models.Command{},
+		encrypt:    encrypt,
+	}
+
+	if encrypt {
+		key, err := loadOrCreateKey()
+		if err != nil {
+			return nil, err
+		}
+		bm.key = key
 	}
 
 	if err := bm.Load(); err != nil && !os.IsNotExist(err) {
@@ -41,6 +68,21 @@ func (bm *BufferManager) Load() error {
 		return err
 	}
 
+	if bytes.HasPrefix(data, encryptedMagic) {
+		if bm.key == nil {
+			key, err := loadOrCreateKey()
+			if err != nil {
+				return err
+			}
+			bm.key = key
+		}
+		plaintext, err := open(bm.key, data)
+		if err != nil {
+			return err
+		}
+		data = plaintext
+	}
+
 	return json.Unmarshal(data, &bm.Commands)
 }
 
@@ -50,7 +92,17 @@ func (bm *BufferManager) Save() error {
 		return err
 	}
 
-	return os.WriteFile(bm.BufferPath, data, 0644)
+	if bm.encrypt {
+		data, err = seal(bm.key, data)
+		if err != nil {
+			return err
+		}
+	}
+
+	// File mode is 0600 unconditionally: the buffer holds exactly the
+	// commands that failed to reach the server, which are often the
+	// sensitive ones needing retry.
+	return os.WriteFile(bm.BufferPath, data, 0600)
 }
 
 func (bm *BufferManager) Add(cmd models.Command) error {
@@ -62,3 +114,19 @@ func (bm *BufferManager) Clear() error {
 	bm.Commands = []models.Command{}
 	return bm.Save()
 }
+
+// Rekey generates a new encryption key, replacing any existing one, and
+// re-saves the current buffer contents under it.
+func (bm *BufferManager) Rekey() error {
+	if !bm.encrypt {
+		return fmt.Errorf("buffer encryption is not enabled (set encrypt_buffer: true in config.json)")
+	}
+
+	key, err := rekey()
+	if err != nil {
+		return err
+	}
+	bm.key = key
+
+	return bm.Save()
+}
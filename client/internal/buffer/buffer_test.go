@@ -0,0 +1,126 @@
+package buffer
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"shell-history-client/internal/models"
+)
+
+func withHome(t *testing.T) string {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	return home
+}
+
+func TestBufferManager_PlaintextRoundTrip(t *testing.T) {
+	withHome(t)
+
+	bm, err := NewBufferManager()
+	if err != nil {
+		t.Fatalf("NewBufferManager: %v", err)
+	}
+
+	if err := bm.Add(models.Command{Command: "ls -la"}); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	reloaded, err := NewBufferManager()
+	if err != nil {
+		t.Fatalf("NewBufferManager (reload): %v", err)
+	}
+	if len(reloaded.Commands) != 1 || reloaded.Commands[0].Command != "ls -la" {
+		t.Errorf("unexpected commands after reload: %+v", reloaded.Commands)
+	}
+}
+
+func TestBufferManager_EncryptedRoundTrip(t *testing.T) {
+	withHome(t)
+
+	bm, err := NewBufferManagerWithEncryption(true)
+	if err != nil {
+		t.Fatalf("NewBufferManagerWithEncryption: %v", err)
+	}
+
+	if err := bm.Add(models.Command{Command: "export AWS_SECRET=shh"}); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	raw, err := os.ReadFile(bm.BufferPath)
+	if err != nil {
+		t.Fatalf("reading buffer file: %v", err)
+	}
+	var probe []models.Command
+	if err := json.Unmarshal(raw, &probe); err == nil {
+		t.Fatal("expected encrypted buffer to not parse as plaintext JSON")
+	}
+
+	reloaded, err := NewBufferManagerWithEncryption(true)
+	if err != nil {
+		t.Fatalf("NewBufferManagerWithEncryption (reload): %v", err)
+	}
+	if len(reloaded.Commands) != 1 || reloaded.Commands[0].Command != "export AWS_SECRET=shh" {
+		t.Errorf("unexpected commands after reload: %+v", reloaded.Commands)
+	}
+}
+
+func TestBufferManager_Rekey(t *testing.T) {
+	withHome(t)
+
+	bm, err := NewBufferManagerWithEncryption(true)
+	if err != nil {
+		t.Fatalf("NewBufferManagerWithEncryption: %v", err)
+	}
+	if err := bm.Add(models.Command{Command: "echo hi"}); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	oldKey, err := os.ReadFile(mustKeyPath(t))
+	if err != nil {
+		t.Fatalf("reading key: %v", err)
+	}
+
+	if err := bm.Rekey(); err != nil {
+		t.Fatalf("Rekey: %v", err)
+	}
+
+	newKey, err := os.ReadFile(mustKeyPath(t))
+	if err != nil {
+		t.Fatalf("reading key after rekey: %v", err)
+	}
+	if string(oldKey) == string(newKey) {
+		t.Error("expected Rekey to generate a new key")
+	}
+
+	reloaded, err := NewBufferManagerWithEncryption(true)
+	if err != nil {
+		t.Fatalf("NewBufferManagerWithEncryption (reload): %v", err)
+	}
+	if len(reloaded.Commands) != 1 || reloaded.Commands[0].Command != "echo hi" {
+		t.Errorf("unexpected commands after rekey+reload: %+v", reloaded.Commands)
+	}
+}
+
+func TestBufferManager_RekeyWithoutEncryptionErrors(t *testing.T) {
+	withHome(t)
+
+	bm, err := NewBufferManager()
+	if err != nil {
+		t.Fatalf("NewBufferManager: %v", err)
+	}
+
+	if err := bm.Rekey(); err == nil {
+		t.Error("expected Rekey to error when encryption isn't enabled")
+	}
+}
+
+func mustKeyPath(t *testing.T) string {
+	t.Helper()
+	path, err := keyPath()
+	if err != nil {
+		t.Fatalf("keyPath: %v", err)
+	}
+	return path
+}
@@ -0,0 +1,122 @@
+package buffer
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// encryptedMagic prefixes sealed buffer files so Load can tell an
+// encrypted blob apart from the plaintext JSON older versions wrote,
+// without needing a separate format flag on disk.
+var encryptedMagic = []byte("SHEB1")
+
+const keySize = 32 // AES-256
+
+func keyPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	configDir := filepath.Join(home, ".config", "shell-history")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "buffer.key"), nil
+}
+
+// loadOrCreateKey reads the buffer encryption key, generating and
+// persisting a new random one on first use.
+func loadOrCreateKey() ([]byte, error) {
+	path, err := keyPath()
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := os.ReadFile(path)
+	if err == nil && len(key) == keySize {
+		return key, nil
+	}
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	key = make([]byte, keySize)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(path, key, 0600); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// rekey generates a fresh encryption key and persists it, overwriting any
+// existing one. Callers are responsible for re-saving the buffer under the
+// new key afterwards.
+func rekey() ([]byte, error) {
+	path, err := keyPath()
+	if err != nil {
+		return nil, err
+	}
+
+	key := make([]byte, keySize)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(path, key, 0600); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// seal encrypts plaintext with AES-256-GCM under key, prefixed with
+// encryptedMagic and a random nonce.
+func seal(key, plaintext []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	ciphertext := gcm.Seal(nil, nonce, plaintext, nil)
+
+	out := make([]byte, 0, len(encryptedMagic)+len(nonce)+len(ciphertext))
+	out = append(out, encryptedMagic...)
+	out = append(out, nonce...)
+	out = append(out, ciphertext...)
+	return out, nil
+}
+
+// open decrypts data previously produced by seal.
+func open(key, data []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	nonceSize := gcm.NonceSize()
+	body := data[len(encryptedMagic):]
+	if len(body) < nonceSize {
+		return nil, fmt.Errorf("encrypted buffer is truncated")
+	}
+
+	nonce, ciphertext := body[:nonceSize], body[nonceSize:]
+	return gcm.Open(nil, nonce, ciphertext, nil)
+}
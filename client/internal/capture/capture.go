@@ -0,0 +1,98 @@
+// Package capture implements a pluggable enrichment pipeline that attaches
+// structured context (cwd, git info, duration, TTY, tags, ...) to a
+// models.Command before it's shipped to the server.
+package capture
+
+import (
+	"sort"
+
+	"shell-history-client/internal/models"
+	"shell-history-client/internal/redaction"
+)
+
+// Context carries inputs enrichers need that aren't part of
+// models.Command itself: values the capture cobra command already knows
+// (its own flags) or reads from the environment.
+type Context struct {
+	CWD        string
+	StartedAt  string
+	DurationMS int64
+	Shell      string
+	Tags       map[string]string
+}
+
+// Enricher attaches one piece of context to cmd. Enrichers that can't
+// determine their field (not in a git repo, no controlling TTY, ...) leave
+// it unset rather than erroring, since enrichment is always best-effort.
+type Enricher interface {
+	Name() string
+	Enrich(ctx *Context, cmd *models.Command)
+}
+
+var registry = map[string]Enricher{
+	"cwd":      cwdEnricher{},
+	"duration": durationEnricher{},
+	"git":      gitEnricher{},
+	"ssh":      sshEnricher{},
+	"tags":     tagsEnricher{},
+	"tty":      ttyEnricher{},
+}
+
+// Names returns the name of every registered enricher, sorted
+// alphabetically.
+func Names() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Pipeline runs a configured set of enrichers over a command.
+type Pipeline struct {
+	enrichers []Enricher
+}
+
+// None is the sentinel enabled-list value meaning "run no enrichers at
+// all", distinct from a nil/empty list (which means "not configured yet,
+// so run all of them"). Config code can't tell an empty slice from a nil
+// one after a JSON round-trip, so "disable everything" needs its own
+// explicit value; see "config set-context-enrichers".
+const None = "none"
+
+// New builds a Pipeline from the given enabled enricher names (see
+// Names), skipping unknown names. A nil or empty list enables every
+// registered enricher, so enrichment works out of the box until a user
+// opts out of specific ones; []string{None} disables all of them.
+func New(enabled []string) *Pipeline {
+	if len(enabled) == 0 {
+		enabled = Names()
+	} else if len(enabled) == 1 && enabled[0] == None {
+		enabled = nil
+	}
+
+	p := &Pipeline{}
+	for _, name := range enabled {
+		if e, ok := registry[name]; ok {
+			p.enrichers = append(p.enrichers, e)
+		}
+	}
+	return p
+}
+
+// Run attaches context to cmd by running every enabled enricher in turn,
+// then redacts any enriched fields that might themselves carry sensitive
+// data (cwd and git branch names are common places for that) through
+// redactor, which may be nil to skip this step.
+func (p *Pipeline) Run(ctx *Context, cmd *models.Command, redactor *redaction.Redactor) {
+	for _, e := range p.enrichers {
+		e.Enrich(ctx, cmd)
+	}
+
+	if redactor == nil {
+		return
+	}
+	cmd.CWD, _ = redactor.Redact(cmd.CWD)
+	cmd.GitBranch, _ = redactor.Redact(cmd.GitBranch)
+}
@@ -0,0 +1,81 @@
+package capture
+
+import (
+	"testing"
+
+	"shell-history-client/internal/models"
+	"shell-history-client/internal/redaction"
+)
+
+func TestPipeline_New_DefaultsToAllEnrichers(t *testing.T) {
+	p := New(nil)
+	if len(p.enrichers) != len(registry) {
+		t.Errorf("expected %d enrichers, got %d", len(registry), len(p.enrichers))
+	}
+}
+
+func TestPipeline_New_NoneDisablesAllEnrichers(t *testing.T) {
+	p := New([]string{None})
+	if len(p.enrichers) != 0 {
+		t.Errorf("expected 0 enrichers for []string{None}, got %d", len(p.enrichers))
+	}
+
+	ctx := &Context{CWD: "/home/me/project", Tags: map[string]string{"env": "prod"}}
+	cmd := &models.Command{}
+	p.Run(ctx, cmd, nil)
+
+	if cmd.CWD != "" || cmd.Tags != nil {
+		t.Errorf("expected no enrichment to run, got %+v", cmd)
+	}
+}
+
+func TestPipeline_New_FiltersUnknownNames(t *testing.T) {
+	p := New([]string{"cwd", "not-a-real-enricher"})
+	if len(p.enrichers) != 1 {
+		t.Errorf("expected 1 enricher, got %d", len(p.enrichers))
+	}
+}
+
+func TestPipeline_Run_AppliesEnrichers(t *testing.T) {
+	p := New([]string{"cwd", "tags"})
+	ctx := &Context{CWD: "/home/me/project", Tags: map[string]string{"env": "prod"}}
+	cmd := &models.Command{}
+
+	p.Run(ctx, cmd, nil)
+
+	if cmd.CWD != "/home/me/project" {
+		t.Errorf("expected CWD to be set, got %q", cmd.CWD)
+	}
+	if cmd.Tags["env"] != "prod" {
+		t.Errorf("expected Tags[env] to be prod, got %q", cmd.Tags["env"])
+	}
+}
+
+func TestPipeline_Run_RedactsCWDAndGitBranch(t *testing.T) {
+	rules := []redaction.Rule{
+		{Name: "secret-dirs", Pattern: `secret-project`, Replacement: "[REDACTED]", Enabled: true},
+	}
+	redactor := redaction.NewRedactor(rules)
+
+	p := New([]string{"cwd"})
+	ctx := &Context{CWD: "/home/me/secret-project"}
+	cmd := &models.Command{GitBranch: "feature/secret-project-rename"}
+
+	p.Run(ctx, cmd, redactor)
+
+	if cmd.CWD != "/home/me/[REDACTED]" {
+		t.Errorf("expected CWD to be redacted, got %q", cmd.CWD)
+	}
+	if cmd.GitBranch != "feature/[REDACTED]-rename" {
+		t.Errorf("expected GitBranch to be redacted, got %q", cmd.GitBranch)
+	}
+}
+
+func TestNames_Sorted(t *testing.T) {
+	names := Names()
+	for i := 1; i < len(names); i++ {
+		if names[i-1] >= names[i] {
+			t.Errorf("expected Names() sorted, got %v", names)
+		}
+	}
+}
@@ -0,0 +1,13 @@
+package capture
+
+import "shell-history-client/internal/models"
+
+type cwdEnricher struct{}
+
+func (cwdEnricher) Name() string { return "cwd" }
+
+func (cwdEnricher) Enrich(ctx *Context, cmd *models.Command) {
+	if ctx.CWD != "" {
+		cmd.CWD = ctx.CWD
+	}
+}
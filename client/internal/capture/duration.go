@@ -0,0 +1,13 @@
+package capture
+
+import "shell-history-client/internal/models"
+
+type durationEnricher struct{}
+
+func (durationEnricher) Name() string { return "duration" }
+
+func (durationEnricher) Enrich(ctx *Context, cmd *models.Command) {
+	cmd.StartedAt = ctx.StartedAt
+	cmd.DurationMS = ctx.DurationMS
+	cmd.Shell = ctx.Shell
+}
@@ -0,0 +1,103 @@
+package capture
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"shell-history-client/internal/models"
+)
+
+type gitEnricher struct{}
+
+func (gitEnricher) Name() string { return "git" }
+
+func (gitEnricher) Enrich(ctx *Context, cmd *models.Command) {
+	cmd.GitRepo, cmd.GitBranch, cmd.GitCommit, cmd.GitDirty = detectGitContext(ctx.CWD)
+}
+
+// detectGitContext walks up from dir looking for a .git directory and, if
+// found, returns the repo name, current branch, a shortened commit SHA,
+// and whether the working tree has uncommitted changes. Any piece it
+// can't determine (detached HEAD, not a git repo, a linked worktree with
+// an unexpected layout) is left empty rather than erroring, since this is
+// best-effort enrichment of the captured command.
+func detectGitContext(dir string) (repo, branch, commit string, dirty bool) {
+	gitDir := findGitDir(dir)
+	if gitDir == "" {
+		return "", "", "", false
+	}
+	repo = filepath.Base(filepath.Dir(gitDir))
+	dirty = isDirty(filepath.Dir(gitDir))
+
+	head, err := os.ReadFile(filepath.Join(gitDir, "HEAD"))
+	if err != nil {
+		return repo, "", "", dirty
+	}
+	ref := strings.TrimSpace(string(head))
+
+	const refPrefix = "ref: "
+	if !strings.HasPrefix(ref, refPrefix) {
+		// Detached HEAD: the file holds the commit hash directly.
+		return repo, "", shortSHA(ref), dirty
+	}
+	refPath := strings.TrimPrefix(ref, refPrefix)
+	branch = strings.TrimPrefix(refPath, "refs/heads/")
+
+	commitBytes, err := os.ReadFile(filepath.Join(gitDir, refPath))
+	if err != nil {
+		return repo, branch, "", dirty
+	}
+	return repo, branch, shortSHA(strings.TrimSpace(string(commitBytes))), dirty
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > 12 {
+		return sha[:12]
+	}
+	return sha
+}
+
+// isDirty shells out to "git status --porcelain" to check for uncommitted
+// changes. Unlike the rest of git detection, this can't be done by reading
+// plumbing files directly without reimplementing a chunk of git's index
+// format, so it's the one place this package invokes the git binary. Any
+// failure (git not installed, not a repo) is treated as "not dirty" rather
+// than erroring, since this is best-effort enrichment.
+func isDirty(repoRoot string) bool {
+	out, err := exec.Command("git", "-C", repoRoot, "status", "--porcelain").Output()
+	if err != nil {
+		return false
+	}
+	return len(strings.TrimSpace(string(out))) > 0
+}
+
+// findGitDir walks up from dir looking for a ".git" entry, returning the
+// git directory it points to (resolving the "gitdir: <path>" indirection
+// used by worktrees and submodules).
+func findGitDir(dir string) string {
+	for {
+		candidate := filepath.Join(dir, ".git")
+		info, err := os.Stat(candidate)
+		if err == nil {
+			if info.IsDir() {
+				return candidate
+			}
+			if contents, err := os.ReadFile(candidate); err == nil {
+				if gitdir := strings.TrimPrefix(strings.TrimSpace(string(contents)), "gitdir: "); gitdir != "" {
+					if !filepath.IsAbs(gitdir) {
+						gitdir = filepath.Join(dir, gitdir)
+					}
+					return gitdir
+				}
+			}
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
@@ -0,0 +1,69 @@
+package capture
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDetectGitContext_NotARepo(t *testing.T) {
+	dir := t.TempDir()
+
+	repo, branch, commit, dirty := detectGitContext(dir)
+	if repo != "" || branch != "" || commit != "" || dirty {
+		t.Errorf("expected empty context outside a repo, got repo=%q branch=%q commit=%q dirty=%v", repo, branch, commit, dirty)
+	}
+}
+
+func TestDetectGitContext_BranchAndCommit(t *testing.T) {
+	root := t.TempDir()
+	gitDir := filepath.Join(root, ".git")
+	if err := os.MkdirAll(filepath.Join(gitDir, "refs", "heads"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(gitDir, "HEAD"), []byte("ref: refs/heads/main\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	sha := "abcdef0123456789abcdef0123456789abcdef01"
+	if err := os.WriteFile(filepath.Join(gitDir, "refs", "heads", "main"), []byte(sha+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	sub := filepath.Join(root, "a", "b")
+	if err := os.MkdirAll(sub, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	repo, branch, commit, _ := detectGitContext(sub)
+	if repo != filepath.Base(root) {
+		t.Errorf("expected repo %q, got %q", filepath.Base(root), repo)
+	}
+	if branch != "main" {
+		t.Errorf("expected branch main, got %q", branch)
+	}
+	if commit != sha[:12] {
+		t.Errorf("expected commit truncated to 12 chars, got %q", commit)
+	}
+}
+
+func TestFindGitDir_GitFileIndirection(t *testing.T) {
+	root := t.TempDir()
+	realGitDir := filepath.Join(root, "actual-git-dir")
+	if err := os.MkdirAll(realGitDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	worktree := filepath.Join(root, "worktree")
+	if err := os.MkdirAll(worktree, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(worktree, ".git"), []byte("gitdir: ../actual-git-dir\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	got := findGitDir(worktree)
+	want, _ := filepath.Abs(realGitDir)
+	gotAbs, _ := filepath.Abs(got)
+	if gotAbs != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,15 @@
+package capture
+
+import (
+	"os"
+
+	"shell-history-client/internal/models"
+)
+
+type sshEnricher struct{}
+
+func (sshEnricher) Name() string { return "ssh" }
+
+func (sshEnricher) Enrich(_ *Context, cmd *models.Command) {
+	cmd.SSHClient = os.Getenv("SSH_CLIENT") != ""
+}
@@ -0,0 +1,13 @@
+package capture
+
+import "shell-history-client/internal/models"
+
+type tagsEnricher struct{}
+
+func (tagsEnricher) Name() string { return "tags" }
+
+func (tagsEnricher) Enrich(ctx *Context, cmd *models.Command) {
+	if len(ctx.Tags) > 0 {
+		cmd.Tags = ctx.Tags
+	}
+}
@@ -0,0 +1,26 @@
+package capture
+
+import (
+	"os"
+	"strings"
+
+	"shell-history-client/internal/models"
+)
+
+type ttyEnricher struct{}
+
+func (ttyEnricher) Name() string { return "tty" }
+
+func (ttyEnricher) Enrich(_ *Context, cmd *models.Command) {
+	cmd.TTY = ttyName()
+}
+
+// ttyName returns the controlling terminal's device path (e.g.
+// /dev/pts/3), or "" if stdin isn't a TTY.
+func ttyName() string {
+	link, err := os.Readlink("/proc/self/fd/0")
+	if err != nil || !strings.HasPrefix(link, "/dev/") {
+		return ""
+	}
+	return link
+}
@@ -2,11 +2,15 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"net"
 	"net/http"
 	"net/url"
+	"shell-history-client/internal/config"
 	"shell-history-client/internal/models"
+	"shell-history-client/internal/oidc"
 	"time"
 )
 
@@ -14,6 +18,15 @@ type APIClient struct {
 	BaseURL    string
 	APIKey     string
 	HTTPClient *http.Client
+
+	// OIDC device-code auth (see WithOIDC). When AccessToken is set, it is
+	// used instead of APIKey, and is transparently refreshed via
+	// RefreshToken on a 401 response.
+	IssuerURL      string
+	ClientID       string
+	AccessToken    string
+	RefreshToken   string
+	OnTokenRefresh func(accessToken, refreshToken string)
 }
 
 func NewAPIClient(baseURL string, apiKey string) *APIClient {
@@ -26,12 +39,114 @@ func NewAPIClient(baseURL string, apiKey string) *APIClient {
 	}
 }
 
+// WithSocket routes every request through a local Unix domain socket (e.g.
+// a co-located "shell-history daemon") instead of connecting over TCP.
+// BaseURL is still used to build request paths, but every connection is
+// dialed against socketPath; this cuts per-command latency down to a local
+// syscall and lets file permissions do auth instead of an API key. If
+// socketPath is empty, c is returned unchanged.
+func (c *APIClient) WithSocket(socketPath string) *APIClient {
+	if socketPath == "" {
+		return c
+	}
+
+	c.HTTPClient.Transport = &http.Transport{
+		DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "unix", socketPath)
+		},
+	}
+	return c
+}
+
+// WithOIDC configures the client to authenticate with a bearer token
+// obtained via the OAuth2 device authorization grant (see
+// "shell-history login") instead of a static API key. onTokenRefresh, if
+// non-nil, is called whenever a 401 triggers a token refresh so the caller
+// can persist the new tokens. If accessToken is empty, c is returned
+// unchanged.
+func (c *APIClient) WithOIDC(issuerURL, clientID, accessToken, refreshToken string, onTokenRefresh func(accessToken, refreshToken string)) *APIClient {
+	if accessToken == "" {
+		return c
+	}
+
+	c.IssuerURL = issuerURL
+	c.ClientID = clientID
+	c.AccessToken = accessToken
+	c.RefreshToken = refreshToken
+	c.OnTokenRefresh = onTokenRefresh
+	return c
+}
+
+// NewAPIClientFromConfig builds an APIClient wired up with whatever
+// transport and auth the user has configured (Unix socket, OIDC device-code
+// tokens, or a static API key). Refreshed OIDC tokens are written back to
+// disk via config.SaveConfig so "shell-history login" only needs to run
+// again once the refresh token itself expires.
+func NewAPIClientFromConfig(cfg config.Config) *APIClient {
+	c := NewAPIClient(cfg.ServerURL, cfg.APIKey).WithSocket(cfg.Socket)
+	return c.WithOIDC(cfg.IssuerURL, cfg.ClientID, cfg.AccessToken, cfg.RefreshToken, func(accessToken, refreshToken string) {
+		cfg.AccessToken = accessToken
+		cfg.RefreshToken = refreshToken
+		config.SaveConfig(cfg)
+	})
+}
+
 func (c *APIClient) addHeaders(req *http.Request) {
-	if c.APIKey != "" {
+	if c.AccessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.AccessToken)
+	} else if c.APIKey != "" {
 		req.Header.Set("X-API-Key", c.APIKey)
 	}
 }
 
+// do sends req and, on a 401 response, transparently refreshes the OIDC
+// access token (if configured) and retries the request once.
+func (c *APIClient) do(req *http.Request) (*http.Response, error) {
+	c.addHeaders(req)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusUnauthorized || c.RefreshToken == "" {
+		return resp, nil
+	}
+	resp.Body.Close()
+
+	if err := c.refreshAccessToken(); err != nil {
+		return resp, nil // fall back to returning the original 401
+	}
+
+	retryReq := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		retryReq.Body = body
+	}
+	c.addHeaders(retryReq)
+
+	return c.HTTPClient.Do(retryReq)
+}
+
+func (c *APIClient) refreshAccessToken() error {
+	tok, err := oidc.RefreshAccessToken(c.IssuerURL, c.ClientID, c.RefreshToken)
+	if err != nil {
+		return err
+	}
+
+	c.AccessToken = tok.AccessToken
+	if tok.RefreshToken != "" {
+		c.RefreshToken = tok.RefreshToken
+	}
+	if c.OnTokenRefresh != nil {
+		c.OnTokenRefresh(c.AccessToken, c.RefreshToken)
+	}
+	return nil
+}
+
 func (c *APIClient) Capture(cmd models.Command) error {
 	data, err := json.Marshal(cmd)
 	if err != nil {
@@ -43,9 +158,8 @@ func (c *APIClient) Capture(cmd models.Command) error {
 		return err
 	}
 	req.Header.Set("Content-Type", "application/json")
-	c.addHeaders(req)
 
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return err
 	}
@@ -61,25 +175,99 @@ func (c *APIClient) Capture(cmd models.Command) error {
 	return nil
 }
 
-func (c *APIClient) Search(query string, hostname string, username string, limit int) ([]models.Command, error) {
-	params := url.Values{}
-	params.Add("q", query)
-	params.Add("limit", fmt.Sprintf("%d", limit))
-	if hostname != "" {
-		params.Add("hostname", hostname)
+// CaptureBatch ships multiple commands in a single JSON POST. It is used by
+// the background flusher to drain the on-disk buffer without making one
+// HTTP round-trip per command.
+func (c *APIClient) CaptureBatch(cmds []models.Command) error {
+	data, err := json.Marshal(cmds)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s/api/v1/commands/batch/", c.BaseURL), bytes.NewBuffer(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return fmt.Errorf("authentication failed: invalid API key")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("server returned status: %d", resp.StatusCode)
 	}
-	if username != "" {
-		params.Add("username", username)
+
+	return nil
+}
+
+// SearchParams describes a single page of a search request. PageSize
+// defaults to 50 if zero. Cursor, when set, fetches the page that follows
+// a previous SearchResult.NextCursor instead of Page.
+type SearchParams struct {
+	Query           string
+	Hostname        string
+	Username        string
+	Page            int
+	PageSize        int
+	Cursor          string
+	Since           string // RFC3339
+	Until           string // RFC3339
+	ExitCode        *int
+	NonZeroExitCode bool
+}
+
+// SearchResult is one page of search results.
+type SearchResult struct {
+	Items      []models.Command
+	NextCursor string
+}
+
+func (c *APIClient) Search(params SearchParams) (*SearchResult, error) {
+	pageSize := params.PageSize
+	if pageSize <= 0 {
+		pageSize = 50
 	}
 
-	url := fmt.Sprintf("%s/api/v1/commands/?%s", c.BaseURL, params.Encode())
-	req, err := http.NewRequest("GET", url, nil)
+	q := url.Values{}
+	q.Add("q", params.Query)
+	q.Add("page_size", fmt.Sprintf("%d", pageSize))
+	if params.Hostname != "" {
+		q.Add("hostname", params.Hostname)
+	}
+	if params.Username != "" {
+		q.Add("username", params.Username)
+	}
+	if params.Cursor != "" {
+		q.Add("cursor", params.Cursor)
+	} else if params.Page > 0 {
+		q.Add("page", fmt.Sprintf("%d", params.Page))
+	}
+	if params.Since != "" {
+		q.Add("since", params.Since)
+	}
+	if params.Until != "" {
+		q.Add("until", params.Until)
+	}
+	if params.ExitCode != nil {
+		q.Add("exit_code", fmt.Sprintf("%d", *params.ExitCode))
+	}
+	if params.NonZeroExitCode {
+		q.Add("exit_code_nonzero", "true")
+	}
+
+	reqURL := fmt.Sprintf("%s/api/v1/commands/?%s", c.BaseURL, q.Encode())
+	req, err := http.NewRequest("GET", reqURL, nil)
 	if err != nil {
 		return nil, err
 	}
-	c.addHeaders(req)
 
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -97,17 +285,134 @@ func (c *APIClient) Search(query string, hostname string, username string, limit
 		return nil, err
 	}
 
+	return &SearchResult{Items: result.Items, NextCursor: result.NextCursor}, nil
+}
+
+// HostListParams filters the hosts returned by ListHosts. All fields are
+// optional.
+type HostListParams struct {
+	Hostname string
+	Username string
+
+	// SeenSince, RFC3339: only hosts seen at or after this time.
+	SeenSince string
+	// InactiveSince, RFC3339: only hosts NOT seen since this time (stale
+	// hosts), used by "hosts prune".
+	InactiveSince string
+}
+
+// ListHosts returns every host matching params, most useful for operators
+// deciding which machines are still reporting and which are stale.
+func (c *APIClient) ListHosts(params HostListParams) ([]models.Host, error) {
+	q := url.Values{}
+	if params.Hostname != "" {
+		q.Add("hostname", params.Hostname)
+	}
+	if params.Username != "" {
+		q.Add("user", params.Username)
+	}
+	if params.SeenSince != "" {
+		q.Add("seen_since", params.SeenSince)
+	}
+	if params.InactiveSince != "" {
+		q.Add("inactive_since", params.InactiveSince)
+	}
+
+	reqURL := fmt.Sprintf("%s/api/v1/hosts/?%s", c.BaseURL, q.Encode())
+	req, err := http.NewRequest("GET", reqURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return nil, fmt.Errorf("authentication failed: invalid API key")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("server returned status: %d", resp.StatusCode)
+	}
+
+	var result models.HostsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+
 	return result.Items, nil
 }
 
+// DeleteHost removes a host. If purgeHistory is true, its captured
+// commands are deleted along with it instead of being orphaned.
+func (c *APIClient) DeleteHost(hostname string, purgeHistory bool) error {
+	q := url.Values{}
+	if purgeHistory {
+		q.Add("purge_history", "true")
+	}
+
+	reqURL := fmt.Sprintf("%s/api/v1/hosts/%s/?%s", c.BaseURL, url.PathEscape(hostname), q.Encode())
+	req, err := http.NewRequest("DELETE", reqURL, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return fmt.Errorf("authentication failed: invalid API key")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("server returned status: %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// RenameHost renames a host as seen by the server, e.g. after reimaging a
+// machine under a new hostname.
+func (c *APIClient) RenameHost(hostname, newHostname string) error {
+	data, err := json.Marshal(map[string]string{"new_hostname": newHostname})
+	if err != nil {
+		return err
+	}
+
+	reqURL := fmt.Sprintf("%s/api/v1/hosts/%s/rename", c.BaseURL, url.PathEscape(hostname))
+	req, err := http.NewRequest("POST", reqURL, bytes.NewBuffer(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return fmt.Errorf("authentication failed: invalid API key")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("server returned status: %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
 func (c *APIClient) GetStats() (map[string]interface{}, error) {
 	req, err := http.NewRequest("GET", fmt.Sprintf("%s/api/v1/stats/", c.BaseURL), nil)
 	if err != nil {
 		return nil, err
 	}
-	c.addHeaders(req)
 
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,144 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"shell-history-client/internal/models"
+)
+
+func TestDo_RefreshesAndRetriesOn401(t *testing.T) {
+	var calls int32
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&calls, 1)
+		if n == 1 {
+			if r.Header.Get("Authorization") != "Bearer old-at" {
+				t.Errorf("expected first attempt to use old-at, got %q", r.Header.Get("Authorization"))
+			}
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer new-at" {
+			t.Errorf("expected retry to use new-at, got %q", r.Header.Get("Authorization"))
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer api.Close()
+
+	issuer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"access_token":"new-at","refresh_token":"new-rt","expires_in":3600}`)
+	}))
+	defer issuer.Close()
+
+	var refreshedAT, refreshedRT string
+	c := NewAPIClient(api.URL, "").WithOIDC(issuer.URL, "cli-id", "old-at", "old-rt", func(accessToken, refreshToken string) {
+		refreshedAT, refreshedRT = accessToken, refreshToken
+	})
+
+	if err := c.Capture(models.Command{Command: "ls"}); err != nil {
+		t.Fatalf("Capture: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 requests to the API, got %d", calls)
+	}
+	if refreshedAT != "new-at" || refreshedRT != "new-rt" {
+		t.Errorf("expected OnTokenRefresh to fire with new tokens, got (%q, %q)", refreshedAT, refreshedRT)
+	}
+}
+
+func TestDo_RefreshFailureReturnsOriginal401(t *testing.T) {
+	var calls int32
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer api.Close()
+
+	issuer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"error":"invalid_grant"}`)
+	}))
+	defer issuer.Close()
+
+	c := NewAPIClient(api.URL, "").WithOIDC(issuer.URL, "cli-id", "old-at", "old-rt", nil)
+
+	err := c.Capture(models.Command{Command: "ls"})
+	if err == nil {
+		t.Fatal("expected an error when the server keeps returning 401")
+	}
+	if calls != 1 {
+		t.Errorf("expected no retry once refresh fails, got %d requests", calls)
+	}
+}
+
+func TestCaptureBatch_SendsAllCommands(t *testing.T) {
+	var gotBody []models.Command
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/commands/batch/" {
+			t.Errorf("expected batch endpoint, got %q", r.URL.Path)
+		}
+		decodeJSON(t, r, &gotBody)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c := NewAPIClient(server.URL, "test-key")
+	cmds := []models.Command{{Command: "ls"}, {Command: "pwd"}}
+	if err := c.CaptureBatch(cmds); err != nil {
+		t.Fatalf("CaptureBatch: %v", err)
+	}
+	if len(gotBody) != 2 {
+		t.Errorf("expected 2 commands in the batch body, got %d", len(gotBody))
+	}
+}
+
+func TestSearch_CursorPagination(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if cursor := r.URL.Query().Get("cursor"); cursor == "" {
+			fmt.Fprint(w, `{"items":[{"command":"ls"}],"next_cursor":"page-2"}`)
+		} else if cursor == "page-2" {
+			if r.URL.Query().Get("page") != "" {
+				t.Errorf("expected page param to be dropped when a cursor is set, got %q", r.URL.Query().Get("page"))
+			}
+			fmt.Fprint(w, `{"items":[{"command":"pwd"}]}`)
+		} else {
+			t.Errorf("unexpected cursor %q", cursor)
+		}
+	}))
+	defer server.Close()
+
+	c := NewAPIClient(server.URL, "test-key")
+
+	first, err := c.Search(SearchParams{Query: "l"})
+	if err != nil {
+		t.Fatalf("Search (first page): %v", err)
+	}
+	if first.NextCursor != "page-2" {
+		t.Errorf("expected NextCursor page-2, got %q", first.NextCursor)
+	}
+	if len(first.Items) != 1 || first.Items[0].Command != "ls" {
+		t.Errorf("unexpected first page items: %+v", first.Items)
+	}
+
+	second, err := c.Search(SearchParams{Query: "l", Cursor: first.NextCursor, Page: 3})
+	if err != nil {
+		t.Fatalf("Search (second page): %v", err)
+	}
+	if second.NextCursor != "" {
+		t.Errorf("expected no further pages, got NextCursor %q", second.NextCursor)
+	}
+	if len(second.Items) != 1 || second.Items[0].Command != "pwd" {
+		t.Errorf("unexpected second page items: %+v", second.Items)
+	}
+}
+
+func decodeJSON(t *testing.T, r *http.Request, v any) {
+	t.Helper()
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		t.Fatalf("decoding request body: %v", err)
+	}
+}
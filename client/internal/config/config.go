@@ -12,13 +12,43 @@ import (
 type Config struct {
 	ServerURL      string
 	APIKey         string
+	Socket         string
 	RedactionRules []redaction.Rule
+	// RedactionPresets lists enabled built-in preset names (see
+	// internal/redaction/presets). The Go field was renamed from the
+	// original "packs" terminology; the JSON key is kept as-is so existing
+	// config files keep working.
+	RedactionPresets []string
+	EncryptBuffer    bool
+
+	// OIDC device-code login (see "shell-history login"). When AccessToken
+	// is set, it takes precedence over APIKey.
+	IssuerURL    string
+	ClientID     string
+	AccessToken  string
+	RefreshToken string
+
+	// ContextEnrichers lists the enabled internal/capture enrichers. An
+	// empty slice means "all of them" (see capture.New).
+	ContextEnrichers []string
+	// Tags are static key/value pairs merged into every captured command,
+	// e.g. "env=prod". Overridden per-invocation by "capture --tag".
+	Tags map[string]string
 }
 
 type configFile struct {
-	ServerURL      string           `json:"server_url"`
-	APIKey         string           `json:"api_key"`
-	RedactionRules []redaction.Rule `json:"redaction_rules,omitempty"`
+	ServerURL        string            `json:"server_url"`
+	APIKey           string            `json:"api_key"`
+	Socket           string            `json:"listen_socket,omitempty"`
+	RedactionRules   []redaction.Rule  `json:"redaction_rules,omitempty"`
+	RedactionPresets []string          `json:"redaction_packs,omitempty"`
+	EncryptBuffer    bool              `json:"encrypt_buffer,omitempty"`
+	IssuerURL        string            `json:"issuer_url,omitempty"`
+	ClientID         string            `json:"client_id,omitempty"`
+	AccessToken      string            `json:"access_token,omitempty"`
+	RefreshToken     string            `json:"refresh_token,omitempty"`
+	ContextEnrichers []string          `json:"context_enrichers,omitempty"`
+	Tags             map[string]string `json:"tags,omitempty"`
 }
 
 func configPath() (string, error) {
@@ -45,6 +75,9 @@ func LoadConfig() Config {
 	if apiKey := os.Getenv("HISTORY_API_KEY"); apiKey != "" {
 		cfg.APIKey = apiKey
 	}
+	if socket := os.Getenv("HISTORY_CLIENT_SOCKET"); socket != "" {
+		cfg.Socket = socket
+	}
 
 	// Load from config file
 	configPath, err := configPath()
@@ -69,9 +102,20 @@ func LoadConfig() Config {
 	if cfg.APIKey == "" && fileCfg.APIKey != "" {
 		cfg.APIKey = fileCfg.APIKey
 	}
+	if cfg.Socket == "" && fileCfg.Socket != "" {
+		cfg.Socket = fileCfg.Socket
+	}
 
-	// Load redaction rules from config file
+	// Load redaction rules and presets from config file
 	cfg.RedactionRules = fileCfg.RedactionRules
+	cfg.RedactionPresets = fileCfg.RedactionPresets
+	cfg.EncryptBuffer = fileCfg.EncryptBuffer
+	cfg.IssuerURL = fileCfg.IssuerURL
+	cfg.ClientID = fileCfg.ClientID
+	cfg.AccessToken = fileCfg.AccessToken
+	cfg.RefreshToken = fileCfg.RefreshToken
+	cfg.ContextEnrichers = fileCfg.ContextEnrichers
+	cfg.Tags = fileCfg.Tags
 
 	return cfg
 }
@@ -83,9 +127,18 @@ func SaveConfig(cfg Config) error {
 	}
 
 	fileCfg := configFile{
-		ServerURL:      cfg.ServerURL,
-		APIKey:         cfg.APIKey,
-		RedactionRules: cfg.RedactionRules,
+		ServerURL:        cfg.ServerURL,
+		APIKey:           cfg.APIKey,
+		Socket:           cfg.Socket,
+		RedactionRules:   cfg.RedactionRules,
+		RedactionPresets: cfg.RedactionPresets,
+		EncryptBuffer:    cfg.EncryptBuffer,
+		IssuerURL:        cfg.IssuerURL,
+		ClientID:         cfg.ClientID,
+		AccessToken:      cfg.AccessToken,
+		RefreshToken:     cfg.RefreshToken,
+		ContextEnrichers: cfg.ContextEnrichers,
+		Tags:             cfg.Tags,
 	}
 
 	data, err := json.MarshalIndent(fileCfg, "", "  ")
@@ -0,0 +1,106 @@
+// Package flusher drains the on-disk command buffer to the server in the
+// background, batching requests and backing off exponentially when the
+// server is unreachable.
+package flusher
+
+import (
+	"math/rand"
+	"time"
+
+	"shell-history-client/internal/buffer"
+	"shell-history-client/internal/client"
+)
+
+const (
+	// DefaultBatchSize caps how many buffered commands are sent per request.
+	DefaultBatchSize = 100
+	// DefaultInterval is how often the flusher attempts a drain when it is
+	// not already backing off.
+	DefaultInterval = 5 * time.Second
+	// MaxBackoff caps the exponential backoff delay after repeated failures.
+	MaxBackoff = 5 * time.Minute
+)
+
+// Flusher periodically drains a BufferManager through an APIClient.
+type Flusher struct {
+	bm        *buffer.BufferManager
+	apiClient *client.APIClient
+	interval  time.Duration
+	batchSize int
+	backoff   time.Duration
+}
+
+// New creates a Flusher that drains bm through apiClient every interval,
+// sending up to batchSize commands per request.
+func New(bm *buffer.BufferManager, apiClient *client.APIClient, interval time.Duration, batchSize int) *Flusher {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+	return &Flusher{
+		bm:        bm,
+		apiClient: apiClient,
+		interval:  interval,
+		batchSize: batchSize,
+		backoff:   interval,
+	}
+}
+
+// Run drains the buffer on a timer until stop is closed. Each failed drain
+// doubles the wait before the next attempt, up to MaxBackoff; a successful
+// drain resets the wait back to the configured interval.
+func (f *Flusher) Run(stop <-chan struct{}) {
+	timer := time.NewTimer(f.interval)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-timer.C:
+			if err := f.drainOnce(); err != nil {
+				f.backoff = nextBackoff(f.backoff)
+			} else {
+				f.backoff = f.interval
+			}
+			timer.Reset(f.backoff)
+		}
+	}
+}
+
+// drainOnce sends up to batchSize buffered commands and removes them from
+// the buffer on success. It leaves the buffer untouched on failure so the
+// next attempt retries the same commands.
+func (f *Flusher) drainOnce() error {
+	if err := f.bm.Load(); err != nil {
+		return err
+	}
+	if len(f.bm.Commands) == 0 {
+		return nil
+	}
+
+	batch := f.bm.Commands
+	if len(batch) > f.batchSize {
+		batch = batch[:f.batchSize]
+	}
+
+	if err := f.apiClient.CaptureBatch(batch); err != nil {
+		return err
+	}
+
+	f.bm.Commands = f.bm.Commands[len(batch):]
+	return f.bm.Save()
+}
+
+// nextBackoff doubles d, caps it at MaxBackoff, and adds up to 20% jitter so
+// many clients retrying at once don't all hammer the server in lockstep.
+func nextBackoff(d time.Duration) time.Duration {
+	d *= 2
+	if d > MaxBackoff {
+		d = MaxBackoff
+	}
+	jitter := time.Duration(rand.Int63n(int64(d) / 5))
+	return d + jitter
+}
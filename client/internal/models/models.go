@@ -10,6 +10,43 @@ type Command struct {
 	SessionID   string `json:"session_id"`
 	Timestamp   string `json:"timestamp,omitempty"`
 	Redacted    bool   `json:"redacted,omitempty"`
+
+	// RedactedBy lists the name of every redaction rule/preset that matched
+	// this command, in firing order, for auditability.
+	RedactedBy []string `json:"redacted_by,omitempty"`
+
+	// Structured metadata, all optional and additive so older clients
+	// remain wire-compatible.
+	DurationMS   int64  `json:"duration_ms,omitempty"`
+	Shell        string `json:"shell,omitempty"`
+	ShellVersion string `json:"shell_version,omitempty"`
+	TTY          string `json:"tty,omitempty"`
+	GitRepo      string `json:"git_repo,omitempty"`
+	GitBranch    string `json:"git_branch,omitempty"`
+	GitCommit    string `json:"git_commit,omitempty"`
+	GitDirty     bool   `json:"git_dirty,omitempty"`
+	StartedAt    string `json:"started_at,omitempty"`
+	FinishedAt   string `json:"finished_at,omitempty"`
+
+	// SSHClient reports whether the command ran in a session with
+	// SSH_CLIENT set, i.e. over a remote connection rather than locally.
+	SSHClient bool `json:"ssh_client,omitempty"`
+
+	// Tags are user-defined key/value pairs, merged from static config
+	// (e.g. "env=prod") and --tag flags passed to capture.
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+// Host is a machine that has reported command history to the server.
+type Host struct {
+	Hostname     string `json:"hostname"`
+	LastSeen     string `json:"last_seen,omitempty"`
+	LastUser     string `json:"last_user,omitempty"`
+	CommandCount int    `json:"command_count,omitempty"`
+}
+
+type HostsResponse struct {
+	Items []Host `json:"items"`
 }
 
 type StatsResponse struct {
@@ -20,4 +57,8 @@ type StatsResponse struct {
 
 type SearchResponse struct {
 	Items []Command `json:"items"`
+
+	// NextCursor is an opaque token for fetching the next page of results,
+	// empty once the last page has been reached.
+	NextCursor string `json:"next_cursor,omitempty"`
 }
@@ -0,0 +1,141 @@
+// Package oidc implements the OAuth 2.0 device authorization grant
+// (RFC 8628) used by "shell-history login" so orgs can authenticate users
+// against their own identity provider instead of distributing a shared
+// API key.
+package oidc
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DeviceAuth is the response to a device authorization request: the code
+// the CLI polls with, and the code/URL to show the user.
+type DeviceAuth struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval"`
+}
+
+// Token is an OAuth2 token response.
+type Token struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"`
+}
+
+// tokenError is the standard OAuth2 error body, used to distinguish
+// "authorization_pending" (keep polling) from a hard failure.
+type tokenError struct {
+	Error string `json:"error"`
+}
+
+// StartDeviceAuth requests a device code from the issuer's device
+// authorization endpoint.
+func StartDeviceAuth(issuerURL, clientID string) (*DeviceAuth, error) {
+	resp, err := http.PostForm(strings.TrimRight(issuerURL, "/")+"/oauth/device/code", url.Values{
+		"client_id": {clientID},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("device authorization request failed: status %d", resp.StatusCode)
+	}
+
+	var auth DeviceAuth
+	if err := json.NewDecoder(resp.Body).Decode(&auth); err != nil {
+		return nil, err
+	}
+	if auth.Interval == 0 {
+		auth.Interval = 5
+	}
+	return &auth, nil
+}
+
+// PollToken polls the issuer's token endpoint for the device code grant
+// until the user authorizes the request, the code expires, or the request
+// is denied.
+func PollToken(issuerURL, clientID string, auth *DeviceAuth) (*Token, error) {
+	interval := time.Duration(auth.Interval) * time.Second
+	deadline := time.Now().Add(time.Duration(auth.ExpiresIn) * time.Second)
+
+	for time.Now().Before(deadline) {
+		time.Sleep(interval)
+
+		tok, pending, err := pollOnce(issuerURL, clientID, auth.DeviceCode)
+		if err != nil {
+			return nil, err
+		}
+		if !pending {
+			return tok, nil
+		}
+	}
+
+	return nil, fmt.Errorf("device code expired before login was completed")
+}
+
+func pollOnce(issuerURL, clientID, deviceCode string) (tok *Token, pending bool, err error) {
+	resp, err := http.PostForm(strings.TrimRight(issuerURL, "/")+"/oauth/token", url.Values{
+		"grant_type":  {"urn:ietf:params:oauth:grant-type:device_code"},
+		"client_id":   {clientID},
+		"device_code": {deviceCode},
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK {
+		var t Token
+		if err := json.NewDecoder(resp.Body).Decode(&t); err != nil {
+			return nil, false, err
+		}
+		return &t, false, nil
+	}
+
+	var tokErr tokenError
+	_ = json.NewDecoder(resp.Body).Decode(&tokErr)
+
+	switch tokErr.Error {
+	case "authorization_pending", "slow_down":
+		return nil, true, nil
+	case "":
+		return nil, false, fmt.Errorf("token request failed: status %d", resp.StatusCode)
+	default:
+		return nil, false, fmt.Errorf("login failed: %s", tokErr.Error)
+	}
+}
+
+// RefreshAccessToken exchanges a refresh token for a new access token.
+func RefreshAccessToken(issuerURL, clientID, refreshToken string) (*Token, error) {
+	resp, err := http.PostForm(strings.TrimRight(issuerURL, "/")+"/oauth/token", url.Values{
+		"grant_type":    {"refresh_token"},
+		"client_id":     {clientID},
+		"refresh_token": {refreshToken},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("token refresh failed: status %d", resp.StatusCode)
+	}
+
+	var t Token
+	if err := json.NewDecoder(resp.Body).Decode(&t); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
@@ -0,0 +1,89 @@
+package oidc
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestStartDeviceAuth(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("client_id") != "cli-id" {
+			t.Errorf("expected client_id=cli-id, got %q", r.FormValue("client_id"))
+		}
+		fmt.Fprint(w, `{"device_code":"dc","user_code":"ABCD-1234","verification_uri":"https://example.com/device","expires_in":600,"interval":1}`)
+	}))
+	defer server.Close()
+
+	auth, err := StartDeviceAuth(server.URL, "cli-id")
+	if err != nil {
+		t.Fatalf("StartDeviceAuth: %v", err)
+	}
+	if auth.UserCode != "ABCD-1234" {
+		t.Errorf("expected user code ABCD-1234, got %q", auth.UserCode)
+	}
+	if auth.Interval != 1 {
+		t.Errorf("expected interval 1, got %d", auth.Interval)
+	}
+}
+
+func TestPollToken_PendingThenSuccess(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error":"authorization_pending"}`)
+			return
+		}
+		fmt.Fprint(w, `{"access_token":"at","refresh_token":"rt","token_type":"Bearer","expires_in":3600}`)
+	}))
+	defer server.Close()
+
+	auth := &DeviceAuth{DeviceCode: "dc", ExpiresIn: 60, Interval: 0}
+	tok, err := PollToken(server.URL, "cli-id", auth)
+	if err != nil {
+		t.Fatalf("PollToken: %v", err)
+	}
+	if tok.AccessToken != "at" || tok.RefreshToken != "rt" {
+		t.Errorf("unexpected token: %+v", tok)
+	}
+	if attempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", attempts)
+	}
+}
+
+func TestPollToken_Denied(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"error":"access_denied"}`)
+	}))
+	defer server.Close()
+
+	auth := &DeviceAuth{DeviceCode: "dc", ExpiresIn: 60, Interval: 0}
+	if _, err := PollToken(server.URL, "cli-id", auth); err == nil {
+		t.Error("expected an error when the user denies the request")
+	}
+}
+
+func TestRefreshAccessToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("grant_type") != "refresh_token" {
+			t.Errorf("expected grant_type=refresh_token, got %q", r.FormValue("grant_type"))
+		}
+		if r.FormValue("refresh_token") != "old-rt" {
+			t.Errorf("expected refresh_token=old-rt, got %q", r.FormValue("refresh_token"))
+		}
+		fmt.Fprint(w, `{"access_token":"new-at","refresh_token":"new-rt","expires_in":3600}`)
+	}))
+	defer server.Close()
+
+	tok, err := RefreshAccessToken(server.URL, "cli-id", "old-rt")
+	if err != nil {
+		t.Fatalf("RefreshAccessToken: %v", err)
+	}
+	if tok.AccessToken != "new-at" {
+		t.Errorf("unexpected access token: %q", tok.AccessToken)
+	}
+}
@@ -0,0 +1,53 @@
+package redaction
+
+// DocumentVersion is bumped whenever the RuleDocument format changes in a
+// way older clients can't read.
+const DocumentVersion = 1
+
+// RuleDocument is the versioned, shareable rule set written by
+// "redaction export" and read by "redaction import", so teams can check a
+// vetted rule set into a repo instead of hand-copying config.json
+// fragments.
+type RuleDocument struct {
+	Version int    `json:"version"`
+	Rules   []Rule `json:"rules"`
+}
+
+// FilterByTag returns the subset of doc's rules carrying tag, or doc
+// unchanged if tag is empty.
+func (doc RuleDocument) FilterByTag(tag string) RuleDocument {
+	if tag == "" {
+		return doc
+	}
+
+	filtered := RuleDocument{Version: doc.Version}
+	for _, rule := range doc.Rules {
+		for _, t := range rule.Tags {
+			if t == tag {
+				filtered.Rules = append(filtered.Rules, rule)
+				break
+			}
+		}
+	}
+	return filtered
+}
+
+// MergeRules merges incoming into existing: a rule sharing a Name with an
+// existing one replaces it in place, preserving its position; anything
+// else is appended.
+func MergeRules(existing, incoming []Rule) []Rule {
+	index := make(map[string]int, len(existing))
+	for i, rule := range existing {
+		index[rule.Name] = i
+	}
+
+	merged := append([]Rule(nil), existing...)
+	for _, rule := range incoming {
+		if i, ok := index[rule.Name]; ok {
+			merged[i] = rule
+			continue
+		}
+		merged = append(merged, rule)
+	}
+	return merged
+}
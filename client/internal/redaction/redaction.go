@@ -1,21 +1,44 @@
 // Package redaction provides client-side command redaction functionality.
 // Users can configure custom regex patterns to redact sensitive data
-// before commands are sent to the server.
+// before commands are sent to the server, and can additionally enable
+// curated built-in presets (see the presets subpackage) instead of
+// hand-writing every regex themselves.
 package redaction
 
 import (
+	"encoding/json"
 	"regexp"
+	"sort"
+
+	"shell-history-client/internal/redaction/presets"
 )
 
-// Rule defines a redaction pattern with its replacement string.
+// Rule defines a redaction pattern with its replacement string. Rules are
+// applied in ascending Priority order, ties broken by insertion order;
+// disabled rules are skipped entirely. Tags are free-form labels (e.g.
+// "team:payments") used to filter "redaction export".
 type Rule struct {
-	Name        string `json:"name"`
-	Pattern     string `json:"pattern"`
-	Replacement string `json:"replacement"`
+	Name        string   `json:"name"`
+	Pattern     string   `json:"pattern"`
+	Replacement string   `json:"replacement"`
+	Priority    int      `json:"priority,omitempty"`
+	Enabled     bool     `json:"enabled"`
+	Tags        []string `json:"tags,omitempty"`
+}
+
+// UnmarshalJSON defaults Enabled to true for rules written before this
+// field existed (and for any document that simply omits it), so upgrading
+// doesn't silently disable every existing rule.
+func (r *Rule) UnmarshalJSON(data []byte) error {
+	type alias Rule
+	aux := &struct{ *alias }{alias: (*alias)(r)}
+	aux.Enabled = true
+	return json.Unmarshal(data, aux)
 }
 
 // compiledRule holds a pre-compiled regex pattern with its replacement.
 type compiledRule struct {
+	name        string
 	pattern     *regexp.Regexp
 	replacement string
 }
@@ -25,14 +48,48 @@ type Redactor struct {
 	rules []compiledRule
 }
 
-// NewRedactor creates a new Redactor with the given rules.
+// NewRedactor creates a new Redactor with the given user-authored rules.
 // Invalid regex patterns are silently skipped.
 func NewRedactor(rules []Rule) *Redactor {
-	r := &Redactor{
-		rules: make([]compiledRule, 0, len(rules)),
+	return NewRedactorWithPresets(nil, rules)
+}
+
+// NewRedactorWithPresets creates a Redactor that applies the named
+// built-in presets ahead of the given user-authored rules, so users get
+// sane defaults without maintaining regex themselves. Rules are ordered
+// by ascending Priority, ties broken by insertion order (presets first,
+// then user rules in config order); disabled rules, unknown preset names,
+// and invalid regex patterns are silently skipped.
+func NewRedactorWithPresets(presetNames []string, rules []Rule) *Redactor {
+	var all []Rule
+	for _, preset := range presets.Resolve(presetNames) {
+		all = append(all, presetRules(preset)...)
+	}
+	all = append(all, rules...)
+
+	sort.SliceStable(all, func(i, j int) bool {
+		return all[i].Priority < all[j].Priority
+	})
+
+	r := &Redactor{}
+	r.addRules(all)
+	return r
+}
+
+func presetRules(p presets.Preset) []Rule {
+	rules := make([]Rule, len(p.Rules))
+	for i, pr := range p.Rules {
+		rules[i] = Rule{Name: pr.Name, Pattern: pr.Pattern, Replacement: pr.Replacement, Enabled: true}
 	}
+	return rules
+}
 
+func (r *Redactor) addRules(rules []Rule) {
 	for _, rule := range rules {
+		if !rule.Enabled {
+			continue
+		}
+
 		// Compile with case-insensitive flag
 		pattern, err := regexp.Compile("(?i)" + rule.Pattern)
 		if err != nil {
@@ -40,30 +97,65 @@ func NewRedactor(rules []Rule) *Redactor {
 			continue
 		}
 		r.rules = append(r.rules, compiledRule{
+			name:        rule.Name,
 			pattern:     pattern,
 			replacement: rule.Replacement,
 		})
 	}
-
-	return r
 }
 
 // Redact applies all redaction rules to the command string.
 // Returns the redacted command and a boolean indicating if any redaction occurred.
 func (r *Redactor) Redact(command string) (string, bool) {
+	result, matched := r.RedactVerbose(command)
+	return result, len(matched) > 0
+}
+
+// RedactVerbose applies all redaction rules to the command string and also
+// reports which rules fired, in firing order, for auditability.
+func (r *Redactor) RedactVerbose(command string) (string, []string) {
 	if len(r.rules) == 0 {
-		return command, false
+		return command, nil
+	}
+
+	result := command
+	var matched []string
+
+	for _, rule := range r.rules {
+		if rule.pattern.MatchString(result) {
+			matched = append(matched, rule.name)
+			result = rule.pattern.ReplaceAllString(result, rule.replacement)
+		}
 	}
 
+	return result, matched
+}
+
+// Trace is one rule's outcome when a command is run through the full rule
+// chain: whether it matched, and the command's state immediately after it
+// ran (unchanged if it didn't match).
+type Trace struct {
+	Name    string
+	Matched bool
+	Result  string
+}
+
+// TraceVerbose runs the full rule chain against command and reports, for
+// every configured rule in firing order, whether it matched. It underlies
+// "shell-history redaction test", which gives users a fast feedback loop
+// when authoring patterns.
+func (r *Redactor) TraceVerbose(command string) []Trace {
 	result := command
-	wasRedacted := false
+	traces := make([]Trace, 0, len(r.rules))
 
 	for _, rule := range r.rules {
 		if rule.pattern.MatchString(result) {
-			wasRedacted = true
 			result = rule.pattern.ReplaceAllString(result, rule.replacement)
+			traces = append(traces, Trace{Name: rule.name, Matched: true, Result: result})
+			continue
 		}
+		traces = append(traces, Trace{Name: rule.name, Matched: false, Result: result})
 	}
 
-	return result, wasRedacted
+	return traces
 }
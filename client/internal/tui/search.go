@@ -0,0 +1,193 @@
+// Package tui implements the interactive, full-screen command picker used
+// by "shell-history search --interactive". It fetches a window of results
+// once and then fuzzy-filters them locally as the user types, so browsing
+// a networked history store feels as snappy as Ctrl-R over a local file.
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"shell-history-client/internal/models"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// FetchWindowSize is how many commands are pulled from the server up front
+// for the picker to filter locally. It trades a slightly larger initial
+// fetch for instant, network-free filtering on every keystroke.
+const FetchWindowSize = 500
+
+// Run launches the full-screen picker over commands and returns the
+// command the user selected, or ("", false) if they cancelled.
+func Run(commands []models.Command) (string, bool) {
+	m := newModel(commands)
+	p := tea.NewProgram(m, tea.WithAltScreen())
+
+	final, err := p.Run()
+	if err != nil {
+		return "", false
+	}
+
+	result := final.(model)
+	return result.selected, result.selected != ""
+}
+
+type model struct {
+	all      []models.Command
+	filtered []scoredCommand
+	input    string
+	cursor   int
+	width    int
+	height   int
+	selected string
+}
+
+type scoredCommand struct {
+	cmd   models.Command
+	score int
+}
+
+func newModel(commands []models.Command) model {
+	m := model{all: commands}
+	m.refilter()
+	return m
+}
+
+func (m model) Init() tea.Cmd {
+	return nil
+}
+
+func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.Type {
+		case tea.KeyEsc, tea.KeyCtrlC:
+			m.selected = ""
+			return m, tea.Quit
+
+		case tea.KeyEnter:
+			if m.cursor < len(m.filtered) {
+				m.selected = m.filtered[m.cursor].cmd.Command
+			}
+			return m, tea.Quit
+
+		case tea.KeyUp, tea.KeyCtrlP:
+			if m.cursor > 0 {
+				m.cursor--
+			}
+			return m, nil
+
+		case tea.KeyDown, tea.KeyCtrlN:
+			if m.cursor < len(m.filtered)-1 {
+				m.cursor++
+			}
+			return m, nil
+
+		case tea.KeyBackspace:
+			if len(m.input) > 0 {
+				m.input = m.input[:len(m.input)-1]
+				m.refilter()
+			}
+			return m, nil
+
+		case tea.KeyRunes:
+			m.input += string(msg.Runes)
+			m.refilter()
+			return m, nil
+		}
+	}
+
+	return m, nil
+}
+
+func (m model) View() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "> %s\n", m.input)
+	fmt.Fprintf(&b, "%d/%d commands\n\n", len(m.filtered), len(m.all))
+
+	maxRows := m.height - 4
+	if maxRows <= 0 || maxRows > len(m.filtered) {
+		maxRows = len(m.filtered)
+	}
+
+	for i := 0; i < maxRows; i++ {
+		sc := m.filtered[i]
+		cursor := "  "
+		if i == m.cursor {
+			cursor = "> "
+		}
+		fmt.Fprintf(&b, "%s%s  [%s@%s %s]\n", cursor, sc.cmd.Command, sc.cmd.Username, sc.cmd.Hostname, sc.cmd.CWD)
+	}
+
+	b.WriteString("\n(enter: paste into shell, esc: cancel, ctrl-p/n or up/down: move)")
+
+	return b.String()
+}
+
+// refilter re-scores m.all against m.input and re-sorts, keeping the
+// highest scoring (best subsequence) matches first. It resets the cursor
+// since the result set under it has changed.
+func (m *model) refilter() {
+	m.cursor = 0
+
+	if m.input == "" {
+		m.filtered = make([]scoredCommand, len(m.all))
+		for i, cmd := range m.all {
+			m.filtered[i] = scoredCommand{cmd: cmd}
+		}
+		return
+	}
+
+	m.filtered = m.filtered[:0]
+	for _, cmd := range m.all {
+		if score, ok := fuzzyScore(m.input, cmd.Command); ok {
+			m.filtered = append(m.filtered, scoredCommand{cmd: cmd, score: score})
+		}
+	}
+
+	sort.SliceStable(m.filtered, func(i, j int) bool {
+		return m.filtered[i].score > m.filtered[j].score
+	})
+}
+
+// fuzzyScore reports whether every rune of needle appears in haystack in
+// order (a subsequence match), and a score that rewards matches where the
+// runes are close together and appear earlier in the string.
+func fuzzyScore(needle, haystack string) (int, bool) {
+	needle = strings.ToLower(needle)
+	haystack = strings.ToLower(haystack)
+
+	score := 0
+	pos := 0
+	lastMatch := -1
+
+	for _, r := range needle {
+		idx := strings.IndexRune(haystack[pos:], r)
+		if idx < 0 {
+			return 0, false
+		}
+		idx += pos
+
+		if lastMatch >= 0 && idx == lastMatch+1 {
+			score += 5 // consecutive runes score higher
+		} else {
+			score += 1
+		}
+		if idx == 0 {
+			score += 2 // matches at the start score higher
+		}
+
+		lastMatch = idx
+		pos = idx + 1
+	}
+
+	return score, true
+}